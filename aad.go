@@ -0,0 +1,42 @@
+// aad.go
+package shamir
+
+import "fmt"
+
+// SplitWithAAD splits the secret into shares bound to aad: each share's
+// integrity tag is an HMAC-SHA256 over the share keyed by aad, so the shares
+// only combine when the same aad is presented. The aad is not stored.
+func SplitWithAAD(secret, aad []byte, t, n int) ([][]byte, error) {
+	if aad == nil {
+		aad = []byte{}
+	}
+	return SplitWithOptions(secret, t, n, SplitOptions{AAD: aad})
+}
+
+// CombineWithAAD reconstructs the secret from shares produced by
+// SplitWithAAD, failing with ErrAADMismatch if aad differs from the value
+// used at split time.
+func CombineWithAAD(shares [][]byte, aad []byte) ([]byte, error) {
+	if aad == nil {
+		aad = []byte{}
+	}
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, err
+	}
+	for _, sh := range shares {
+		info, err := parseFrame(sh)
+		if err != nil {
+			return nil, err
+		}
+		if info.integrity() != integrityHMAC {
+			return nil, fmt.Errorf("%w: share %d is not bound to associated data", ErrAADMismatch, info.index)
+		}
+	}
+	xs, data, err := collectSharesAAD(shares, int(h[5]), true, aad)
+	if err != nil {
+		return nil, fmt.Errorf("combine with aad: %w", err)
+	}
+	return unpackSecret(shares[0], interpolate(lagrange(xs), data))
+}
@@ -0,0 +1,57 @@
+// basis.go
+package shamir
+
+import "fmt"
+
+// Basis holds precomputed Lagrange weights at x=0 for a fixed set of share
+// indices, so repeated reconstructions from the same custodians skip the
+// O(t²) weight computation.
+type Basis struct {
+	Indices []byte // share indices, in the order shares must be supplied
+	Weights []byte // Weights[i] pairs with Indices[i]
+}
+
+// LagrangeBasis precomputes the reconstruction weights for indices.
+func LagrangeBasis(indices []byte) (Basis, error) {
+	if len(indices) < 2 {
+		return Basis{}, fmt.Errorf("%w: need at least 2 indices", ErrInsufficientShares)
+	}
+	seen := make(map[byte]bool, len(indices))
+	for _, x := range indices {
+		if x == 0 {
+			return Basis{}, fmt.Errorf("%w: index 0", ErrInvalidIndex)
+		}
+		if seen[x] {
+			return Basis{}, fmt.Errorf("%w: %d", ErrDuplicateIndex, x)
+		}
+		seen[x] = true
+	}
+	idx := make([]byte, len(indices))
+	copy(idx, indices)
+	return Basis{Indices: idx, Weights: lagrange(idx)}, nil
+}
+
+// CombineWithBasis reconstructs the secret from shares whose indices match
+// basis.Indices position for position. Shares are still fully validated.
+func CombineWithBasis(basis Basis, shares [][]byte) ([]byte, error) {
+	if len(shares) != len(basis.Indices) || len(basis.Weights) != len(basis.Indices) {
+		return nil, fmt.Errorf("%w: basis covers %d shares, got %d", ErrInvalidParams, len(basis.Indices), len(shares))
+	}
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, err
+	}
+	if int(h[5]) != len(shares) {
+		return nil, fmt.Errorf("%w: basis size %d differs from threshold %d", ErrInvalidParams, len(shares), h[5])
+	}
+	xs, data, err := collectShares(shares, len(shares), true)
+	if err != nil {
+		return nil, err
+	}
+	for i, x := range xs {
+		if x != basis.Indices[i] {
+			return nil, fmt.Errorf("%w: position %d holds share %d, basis expects %d", ErrInvalidIndex, i, x, basis.Indices[i])
+		}
+	}
+	return unpackSecret(shares[0], interpolate(basis.Weights, data))
+}
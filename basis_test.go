@@ -0,0 +1,127 @@
+// basis_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCombineWithBasis(t *testing.T) {
+	secret := []byte("correct horse battery staple")
+	shares, err := Split(secret, 5, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		pick    []int // positions in shares
+		wantErr error
+	}{
+		{"first five", []int{0, 1, 2, 3, 4}, nil},
+		{"last five", []int{3, 4, 5, 6, 7}, nil},
+		{"scattered", []int{7, 0, 5, 2, 3}, nil},
+		{"too few", []int{0, 1, 2}, ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var subset [][]byte
+			var indices []byte
+			for _, p := range tt.pick {
+				subset = append(subset, shares[p])
+				indices = append(indices, shares[p][9])
+			}
+			basis, err := LagrangeBasis(indices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := CombineWithBasis(basis, subset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CombineWithBasis error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			want, err := Combine(subset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) || !bytes.Equal(got, secret) {
+				t.Fatalf("CombineWithBasis = %q, Combine = %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLagrangeBasisRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		indices []byte
+		wantErr error
+	}{
+		{"single", []byte{1}, ErrInsufficientShares},
+		{"zero", []byte{1, 0, 3}, ErrInvalidIndex},
+		{"duplicate", []byte{1, 2, 2}, ErrDuplicateIndex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LagrangeBasis(tt.indices); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LagrangeBasis(%v) error = %v, want %v", tt.indices, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+var benchThresholds = []int{3, 16, 64, 255}
+
+func BenchmarkLagrangeBasis(b *testing.B) {
+	for _, k := range benchThresholds {
+		indices := make([]byte, k)
+		for i := range indices {
+			indices[i] = byte(i + 1)
+		}
+		b.Run(fmt.Sprintf("t=%d", k), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := LagrangeBasis(indices); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkCombineWithBasis compares repeated reconstructions from the same
+// index set with and without a precomputed basis.
+func BenchmarkCombineWithBasis(b *testing.B) {
+	secret := bytes.Repeat([]byte{0xa5}, 32)
+	for _, k := range benchThresholds {
+		shares, err := Split(secret, k, 255)
+		if err != nil {
+			b.Fatal(err)
+		}
+		shares = shares[:k]
+		indices := make([]byte, k)
+		for i, s := range shares {
+			indices[i] = s[9]
+		}
+		basis, err := LagrangeBasis(indices)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("Combine/t=%d", k), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := Combine(shares); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Basis/t=%d", k), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := CombineWithBasis(basis, shares); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// clock.go
+package shamir
+
+import "time"
+
+// Clock abstracts time so scheduling can be driven deterministically in tests.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the subset of *time.Ticker used by the package.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the real wall clock.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (st systemTicker) C() <-chan time.Time { return st.t.C }
+
+func (st systemTicker) Stop() { st.t.Stop() }
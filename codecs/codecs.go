@@ -0,0 +1,65 @@
+// codecs/codecs.go
+
+// Package codecs provides compact binary share envelopes. Importing it
+// registers the "cbor" and "msgpack" codecs with shamir.RegisterCodec.
+package codecs
+
+import (
+	"bytes"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/oarkflow/shamir"
+)
+
+var (
+	// CBOR encodes shares as RFC 8949 CBOR maps keyed like the JSON envelope.
+	CBOR shamir.ShareCodec = cborCodec{}
+	// MsgPack encodes shares as MessagePack maps keyed like the JSON envelope.
+	MsgPack shamir.ShareCodec = msgpackCodec{}
+)
+
+func init() {
+	shamir.RegisterCodec(CBOR)
+	shamir.RegisterCodec(MsgPack)
+}
+
+type cborCodec struct{}
+
+func (cborCodec) Name() string { return "cbor" }
+
+func (cborCodec) Marshal(j *shamir.ShareJSON) ([]byte, error) { return cbor.Marshal(j) }
+
+func (cborCodec) Unmarshal(data []byte) (*shamir.ShareJSON, error) {
+	var j shamir.ShareJSON
+	if err := cbor.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return "msgpack" }
+
+// Marshal keys fields by their json tags so the map matches the JSON envelope.
+func (msgpackCodec) Marshal(j *shamir.ShareJSON) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(j); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte) (*shamir.ShareJSON, error) {
+	var j shamir.ShareJSON
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	if err := dec.Decode(&j); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
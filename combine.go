@@ -0,0 +1,256 @@
+// combine.go
+package shamir
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// CombineDedup reconstructs the secret after dropping exact duplicate shares.
+// Two shares with the same index but different bytes are a genuine conflict
+// (e.g. an old and a refreshed copy mixed together) and produce an error
+// wrapping ErrConflictingIndex that names the index.
+func CombineDedup(shares [][]byte) ([]byte, error) {
+	unique := make([][]byte, 0, len(shares))
+	byIndex := make(map[byte][]byte, len(shares))
+	for i, s := range shares {
+		if len(s) < headLen {
+			return nil, fmt.Errorf("%w: share %d too short", ErrLengthMismatch, i)
+		}
+		if prev, ok := byIndex[s[9]]; ok {
+			if !bytes.Equal(prev, s) {
+				return nil, fmt.Errorf("%w: %d", ErrConflictingIndex, s[9])
+			}
+			continue
+		}
+		byIndex[s[9]] = s
+		unique = append(unique, s)
+	}
+	return Combine(unique)
+}
+
+// CombineMap reconstructs the secret from shares keyed by index, as
+// returned by a storage BatchGet. Each key must match the index in its
+// share's header, or it fails with ErrInvalidIndex. Shares are combined in
+// ascending index order.
+func CombineMap(shares map[byte][]byte) ([]byte, error) {
+	list := make([][]byte, 0, len(shares))
+	for idx := 0; idx < 256; idx++ {
+		s, ok := shares[byte(idx)]
+		if !ok {
+			continue
+		}
+		if len(s) < headLen {
+			return nil, fmt.Errorf("%w: share %d too short", ErrLengthMismatch, idx)
+		}
+		if s[9] != byte(idx) {
+			return nil, fmt.Errorf("%w: share stored under %d has index %d", ErrInvalidIndex, idx, s[9])
+		}
+		list = append(list, s)
+	}
+	return Combine(list)
+}
+
+// CombineWithKnownParams reconstructs the secret using parameters the
+// caller already knows rather than trusting the share headers. Every share
+// given is verified and its header must agree with threshold, total and
+// secretLen (the payload length recorded in the header), or it fails with
+// ErrHeaderMismatch naming the share's index.
+func CombineWithKnownParams(shares [][]byte, threshold, total, secretLen int) ([]byte, error) {
+	if err := validateParams(threshold, total); err != nil {
+		return nil, err
+	}
+	if secretLen < 0 || secretLen > 0xFFFF {
+		return nil, fmt.Errorf("%w: secret length %d", ErrInvalidParams, secretLen)
+	}
+	for i, s := range shares {
+		info, err := parseShare(s)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		switch {
+		case int(info.threshold) != threshold:
+			return nil, fmt.Errorf("%w: share %d has threshold %d, want %d", ErrHeaderMismatch, info.index, info.threshold, threshold)
+		case int(info.total) != total:
+			return nil, fmt.Errorf("%w: share %d has total %d, want %d", ErrHeaderMismatch, info.index, info.total, total)
+		case len(info.payload) != secretLen:
+			return nil, fmt.Errorf("%w: share %d has length %d, want %d", ErrHeaderMismatch, info.index, len(info.payload), secretLen)
+		case int(info.index) > total:
+			return nil, fmt.Errorf("%w: %d exceeds total %d", ErrInvalidIndex, info.index, total)
+		}
+	}
+	return combine(sortByIndex(shares), threshold, true)
+}
+
+// CombineVerified reconstructs the secret from the first threshold shares and
+// cross-checks it against every extra share: the recovered polynomial is
+// evaluated at each held-out index and must reproduce that share's payload.
+// At least threshold+1 shares are required. A disagreement means one of the
+// shares is corrupt even though its CRC is valid. Shares are sorted by index
+// first, so the reconstruction uses the threshold lowest indices.
+func CombineVerified(shares [][]byte) ([]byte, error) {
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, err
+	}
+	t := int(h[5])
+	if len(shares) < t+1 {
+		return nil, fmt.Errorf("%w: need %d shares to verify, have %d", ErrInsufficientShares, t+1, len(shares))
+	}
+	xs, data, err := collectShares(shares, len(shares), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range shares {
+		if int(s[5]) != t {
+			return nil, fmt.Errorf("%w: share %d", ErrHeaderMismatch, s[9])
+		}
+	}
+	secret := interpolate(lagrange(xs[:t]), data[:t])
+	for k := t; k < len(xs); k++ {
+		want := interpolate(lagrangeAt(xs[:t], xs[k]), data[:t])
+		if !bytes.Equal(want, data[k]) {
+			return nil, fmt.Errorf("%w: share %d disagrees with the reconstruction", ErrInconsistentShares, xs[k])
+		}
+	}
+	return unpackSecret(shares[0], secret)
+}
+
+// lagrangeAt computes the Lagrange basis weights for evaluating the
+// polynomial through xs at point x.
+func lagrangeAt(xs []byte, x byte) []byte {
+	lags := make([]byte, len(xs))
+	for i := range xs {
+		num, den := byte(1), byte(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			num = mul(num, x^xs[j])
+			den = mul(den, xs[i]^xs[j])
+		}
+		d1, _ := inv(den)
+		lags[i] = mul(num, d1)
+	}
+	return lags
+}
+
+// CombineRange reconstructs only secret bytes [start, end). Shares are
+// validated exactly as in Combine; only the interpolation is restricted.
+// Compressed shares are rejected, since a byte range of the compressed
+// secret is meaningless.
+func CombineRange(shares [][]byte, start, end int) ([]byte, error) {
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, err
+	}
+	if h[4] == versionV2 && len(h) > headLen && h[headLen]&flagCompressed != 0 {
+		return nil, fmt.Errorf("%w: cannot reconstruct a range of a compressed secret", ErrInvalidParams)
+	}
+	xs, data, err := collectShares(shares, int(h[5]), true)
+	if err != nil {
+		return nil, err
+	}
+	secretLen := len(data[0])
+	if start < 0 || end < start || end > secretLen {
+		return nil, fmt.Errorf("%w: range [%d, %d) outside secret of %d bytes", ErrInvalidParams, start, end, secretLen)
+	}
+	window := make([][]byte, len(data))
+	for i, d := range data {
+		window[i] = d[start:end]
+	}
+	return interpolate(lagrange(xs), window), nil
+}
+
+// CombineWithTrace is Combine that also reports, in ascending order, the
+// indices of the shares that took part in the interpolation. When more than
+// threshold shares are supplied only the lowest threshold indices are used,
+// and the rest are not validated.
+func CombineWithTrace(shares [][]byte) (secret []byte, usedIndices []byte, err error) {
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	xs, data, err := collectShares(shares, int(h[5]), true)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err = unpackSecret(shares[0], interpolate(lagrange(xs), data))
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret, xs, nil
+}
+
+// CombineExact is a strict Combine: it fails unless exactly threshold shares
+// are supplied, instead of silently using the first threshold of a larger
+// set. Passing the wrong number of shares is reported as ErrInvalidParams
+// (too many) or ErrInsufficientShares (too few).
+func CombineExact(shares [][]byte) ([]byte, error) {
+	h, err := firstHeader(sortByIndex(shares))
+	if err != nil {
+		return nil, err
+	}
+	t := int(h[5])
+	switch {
+	case len(shares) < t:
+		return nil, fmt.Errorf("%w: have %d, need exactly %d", ErrInsufficientShares, len(shares), t)
+	case len(shares) > t:
+		return nil, fmt.Errorf("%w: got %d shares, threshold is exactly %d", ErrInvalidParams, len(shares), t)
+	}
+	return Combine(shares)
+}
+
+// CombineExcluding reconstructs the secret after dropping every share whose
+// index is in revoked, so a departed custodian's share cannot contribute even
+// if it is presented. Reconstruction fails with ErrInsufficientShares when
+// the remaining shares fall below the threshold.
+func CombineExcluding(shares [][]byte, revoked []byte) ([]byte, error) {
+	kept := make([][]byte, 0, len(shares))
+	for _, s := range shares {
+		if len(s) >= headLen && bytes.IndexByte(revoked, s[9]) >= 0 {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	if dropped := len(shares) - len(kept); dropped > 0 && len(kept) > 0 {
+		if t, err := ShareThreshold(kept[0]); err == nil && len(kept) < int(t) {
+			return nil, fmt.Errorf("%w: %d shares left after dropping %d revoked, need %d", ErrInsufficientShares, len(kept), dropped, t)
+		}
+	}
+	return Combine(kept)
+}
+
+// ShareValidator is a caller-supplied check run on a share before it is
+// combined; see CombineWithValidator.
+type ShareValidator func(share []byte) error
+
+// CombineWithValidator is Combine with domain checks plugged in, for the
+// case where exactly threshold shares leave no spare to cross-check and a
+// corrupt share with a valid CRC would otherwise go unnoticed. checkShare
+// runs on every input share before reconstruction and checkSecret on the
+// result; either may be nil. A rejection fails the combine with the
+// validator's error wrapped, and a rejected secret is wiped.
+func CombineWithValidator(shares [][]byte, checkShare ShareValidator, checkSecret func(secret []byte) error) ([]byte, error) {
+	if checkShare != nil {
+		for i, s := range shares {
+			if err := checkShare(s); err != nil {
+				return nil, fmt.Errorf("shamir: share %d rejected: %w", i, err)
+			}
+		}
+	}
+	secret, err := Combine(shares)
+	if err != nil {
+		return nil, err
+	}
+	if checkSecret != nil {
+		if err := checkSecret(secret); err != nil {
+			wipe(secret)
+			return nil, fmt.Errorf("shamir: reconstructed secret rejected: %w", err)
+		}
+	}
+	return secret, nil
+}
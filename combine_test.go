@@ -0,0 +1,98 @@
+// combine_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// permutations returns every ordering of shares.
+func permutations(shares [][]byte) [][][]byte {
+	if len(shares) <= 1 {
+		return [][][]byte{shares}
+	}
+	var out [][][]byte
+	for i := range shares {
+		rest := make([][]byte, 0, len(shares)-1)
+		rest = append(rest, shares[:i]...)
+		rest = append(rest, shares[i+1:]...)
+		for _, p := range permutations(rest) {
+			out = append(out, append([][]byte{shares[i]}, p...))
+		}
+	}
+	return out
+}
+
+func TestCombineVariantsIgnoreOrder(t *testing.T) {
+	secret := []byte("order must not matter")
+	aad := []byte("context")
+	plain, err := Split(secret, 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digested, err := SplitWithOptions(secret, 3, 4, SplitOptions{EmbedDigest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, err := SplitWithAAD(secret, aad, 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		shares  [][]byte
+		combine func([][]byte) ([]byte, error)
+		want    []byte
+	}{
+		{"CombineVerified", plain, CombineVerified, secret},
+		{"CombineRange", plain, func(s [][]byte) ([]byte, error) { return CombineRange(s, 6, 10) }, secret[6:10]},
+		{"CombineWithAAD", bound, func(s [][]byte) ([]byte, error) { return CombineWithAAD(s, aad) }, secret},
+		{"CombineChecked", digested, CombineChecked, secret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, p := range permutations(tt.shares) {
+				got, err := tt.combine(p)
+				if err != nil {
+					t.Fatalf("order %v: %v", indicesOf(p), err)
+				}
+				if !bytes.Equal(got, tt.want) {
+					t.Fatalf("order %v: got %q, want %q", indicesOf(p), got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// CombineVerified reconstructs from the lowest indices, so a corrupt share
+// is blamed the same way whatever the input order.
+func TestCombineVerifiedBlameIgnoresOrder(t *testing.T) {
+	shares, err := Split([]byte("one bad apple"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares[2] = edited(shares[2], true, func(b []byte) []byte { b[headLen] ^= 1; return b })
+
+	var first string
+	for _, p := range permutations(shares) {
+		_, err := CombineVerified(p)
+		if !errors.Is(err, ErrInconsistentShares) {
+			t.Fatalf("order %v: error = %v, want ErrInconsistentShares", indicesOf(p), err)
+		}
+		if first == "" {
+			first = err.Error()
+		} else if err.Error() != first {
+			t.Fatalf("order %v: error %q, other orders give %q", indicesOf(p), err, first)
+		}
+	}
+}
+
+func indicesOf(shares [][]byte) []byte {
+	out := make([]byte, len(shares))
+	for i, s := range shares {
+		out[i] = s[9]
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+// combinecache.go
+package shamir
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CombineCacheStats reports CombineCache effectiveness.
+type CombineCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// CombineCache memoizes Lagrange weights per set of share indices, bounded
+// by an LRU. Only the weights are cached: they depend on nothing but the
+// indices and are public. Secrets and share payloads are never retained.
+// It is safe for concurrent use. A nil *CombineCache behaves like Combine.
+type CombineCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List // front = most recently used; values are *basisEntry
+	entries map[string]*list.Element
+	stats   CombineCacheStats
+}
+
+type basisEntry struct {
+	key     string // the sorted indices
+	weights []byte
+}
+
+// NewCombineCache creates a cache holding the weights of up to capacity
+// index sets. A capacity below 1 is treated as 1.
+func NewCombineCache(capacity int) *CombineCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &CombineCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *CombineCache) Stats() CombineCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Size = c.order.Len()
+	return s
+}
+
+// Combine is Combine with the Lagrange weights looked up in the cache.
+// Shares are validated exactly as in Combine.
+func (c *CombineCache) Combine(shares [][]byte) ([]byte, error) {
+	if c == nil {
+		return Combine(shares)
+	}
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, err
+	}
+	xs, data, err := collectShares(shares, int(h[5]), true)
+	if err != nil {
+		return nil, err
+	}
+	return unpackSecret(shares[0], interpolate(c.weights(xs), data))
+}
+
+// weights returns the cached basis for the sorted indices xs, computing it
+// on a miss. The returned slice must not be modified.
+func (c *CombineCache) weights(xs []byte) []byte {
+	key := string(xs)
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		c.stats.Hits++
+		w := el.Value.(*basisEntry).weights
+		c.mu.Unlock()
+		return w
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	w := lagrange(xs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		// another goroutine filled it meanwhile
+		c.order.MoveToFront(el)
+		return el.Value.(*basisEntry).weights
+	}
+	c.entries[key] = c.order.PushFront(&basisEntry{key: key, weights: w})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*basisEntry).key)
+		c.stats.Evictions++
+	}
+	return w
+}
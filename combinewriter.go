@@ -0,0 +1,91 @@
+// combinewriter.go
+package shamir
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// combineBlock is the number of secret bytes CombineToWriter reconstructs
+// per write.
+const combineBlock = 4096
+
+// CombineToWriter reconstructs the secret like Combine but streams it to w
+// in blocks instead of returning it, so the whole secret is never held in
+// one buffer. It returns the number of bytes written. The block buffer is
+// wiped before returning. If w fails part way, the bytes already written
+// stay written. Compressed shares are decompressed on the fly, subject to
+// MaxSecretLen like Combine.
+func CombineToWriter(w io.Writer, shares [][]byte) (int, error) {
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return 0, err
+	}
+	xs, data, err := collectShares(shares, int(h[5]), true)
+	if err != nil {
+		return 0, err
+	}
+	var src io.Reader = &interpReader{lags: lagrange(xs), data: data}
+	limit := -1
+	if info, _ := parseFrame(shares[0]); info.flags&flagCompressed != 0 {
+		zr, err := gzip.NewReader(src)
+		if err != nil {
+			return 0, fmt.Errorf("%w: compressed secret: %w", ErrInconsistentShares, err)
+		}
+		src, limit = zr, MaxSecretLen
+	}
+	buf := make([]byte, combineBlock)
+	defer wipe(buf)
+	written := 0
+	for {
+		n, rerr := src.Read(buf)
+		if limit >= 0 && written+n > limit {
+			return written, fmt.Errorf("%w: compressed secret is larger than %d bytes", ErrSecretTooLarge, limit)
+		}
+		if n > 0 {
+			m, werr := w.Write(buf[:n])
+			written += m
+			if werr != nil {
+				return written, werr
+			}
+			if m < n {
+				return written, io.ErrShortWrite
+			}
+		}
+		if rerr == io.EOF {
+			return written, nil
+		}
+		if rerr != nil {
+			if limit >= 0 {
+				rerr = fmt.Errorf("%w: compressed secret: %w", ErrInconsistentShares, rerr)
+			}
+			return written, rerr
+		}
+	}
+}
+
+// interpReader yields the interpolated secret one block at a time.
+type interpReader struct {
+	lags []byte
+	data [][]byte
+	off  int
+}
+
+func (r *interpReader) Read(p []byte) (int, error) {
+	secretLen := len(r.data[0])
+	if r.off >= secretLen {
+		return 0, io.EOF
+	}
+	n := min(len(p), secretLen-r.off)
+	for j := 0; j < n; j++ {
+		var v byte
+		for i := range r.lags {
+			v ^= mul(r.data[i][r.off+j], r.lags[i])
+		}
+		p[j] = v
+	}
+	r.off += n
+	return n, nil
+}
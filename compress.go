@@ -0,0 +1,78 @@
+// compress.go
+package shamir
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// MaxSecretLen bounds the secret length the package will allocate for when
+// parsing untrusted input: the length declared in a share header, a JSON
+// envelope's payload, the secretLen given to CombineWithParams, and the
+// size a compressed share set may expand to (so a forged share set can't be
+// used as a decompression bomb). Exceeding it fails with ErrSecretTooLarge.
+// Uncompressed shares can't declare more than 65535 bytes, so lowering it
+// is what tightens their parsing. Set it once at startup; it is not
+// synchronized.
+var MaxSecretLen = 16 << 20
+
+// SplitCompressed gzips the secret and splits the compressed bytes. The
+// shares carry a compression flag (v2 format) and Combine decompresses the
+// reconstruction transparently.
+//
+// Share length tracks the compressed size, so this only saves space for
+// compressible secrets; for random data such as keys the shares get larger.
+// The compressed size of the secret is also visible to every custodian.
+func SplitCompressed(secret []byte, t, n int) ([][]byte, error) {
+	return SplitWithOptions(secret, t, n, SplitOptions{Compress: true})
+}
+
+func compressSecret(secret []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(secret); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() > 0xFFFF {
+		wipe(buf.Bytes())
+		return nil, fmt.Errorf("%w: compressed secret longer than 65535 bytes", ErrInvalidParams)
+	}
+	return buf.Bytes(), nil
+}
+
+// unpackSecret undoes any transformation recorded in the header of share
+// (the first share the secret was reconstructed from). The reconstructed
+// bytes are wiped once they have been decompressed.
+func unpackSecret(share []byte, secret []byte) ([]byte, error) {
+	info, err := parseFrame(share)
+	if err != nil {
+		return nil, err
+	}
+	if info.flags&flagCompressed == 0 {
+		return secret, nil
+	}
+	defer wipe(secret)
+	zr, err := gzip.NewReader(bytes.NewReader(secret))
+	if err != nil {
+		return nil, fmt.Errorf("%w: compressed secret: %w", ErrInconsistentShares, err)
+	}
+	limit := MaxSecretLen
+	out, err := io.ReadAll(io.LimitReader(zr, int64(limit)+1))
+	if err == nil && len(out) > limit {
+		wipe(out)
+		return nil, fmt.Errorf("%w: compressed secret is larger than %d bytes", ErrSecretTooLarge, limit)
+	}
+	if err != nil {
+		wipe(out)
+		return nil, fmt.Errorf("%w: compressed secret: %w", ErrInconsistentShares, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,63 @@
+// deterministic.go
+package shamir
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"fmt"
+)
+
+// pbkdf2Iterations is the work factor applied to the recovery passphrase.
+const pbkdf2Iterations = 600000
+
+// SplitDeterministic splits the secret with polynomial coefficients derived
+// from the passphrase, salt and secret, so the same inputs always produce
+// byte-identical shares. This lets a disaster-recovery process regenerate
+// lost shares instead of storing them.
+//
+// Security trade-off: the coefficients are no longer information-theoretically
+// random. Anyone who learns the passphrase and salt, plus the secret, can
+// recompute every share, and an attacker holding fewer than t shares can
+// brute-force a weak passphrase offline. Use a high-entropy passphrase and
+// prefer Split whenever reproducibility isn't required.
+func SplitDeterministic(secret []byte, t, n int, kdfSalt []byte, passphrase string) ([][]byte, error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: empty passphrase", ErrInvalidParams)
+	}
+	stretched, err := pbkdf2.Key(sha256.New, passphrase, kdfSalt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(stretched)
+	// Bind the stream to the scheme and the secret so different secrets never
+	// share coefficients under the same passphrase.
+	digest := sha256.Sum256(secret)
+	info := fmt.Sprintf("shamir-deterministic-v1|%d|%d|%x", t, n, digest)
+	keyIV, err := hkdf.Key(sha256.New, stretched, kdfSalt, info, 32+aes.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(keyIV)
+	block, err := aes.NewCipher(keyIV[:32])
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.StreamReader{S: cipher.NewCTR(block, keyIV[32:]), R: zeroReader{}}
+	return split(stream, secret, t, n)
+}
+
+// zeroReader yields an endless stream of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
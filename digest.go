@@ -0,0 +1,65 @@
+// digest.go
+package shamir
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+)
+
+var (
+	// ErrNoDigest is returned by CombineChecked for shares without an embedded digest.
+	ErrNoDigest = errors.New("shamir: shares carry no secret digest")
+	// ErrDigestMismatch is returned when the reconstructed secret doesn't match the embedded digest.
+	ErrDigestMismatch = errors.New("shamir: reconstructed secret does not match digest")
+)
+
+// CombineChecked reconstructs the secret and verifies it against the digest
+// embedded at split time (see SplitOptions.EmbedDigest). This catches
+// reconstructions from shares of different secrets that happen to share a
+// length and threshold, which header checks alone cannot detect. The digest
+// is read from the lowest-index share, as Combine orders them.
+func CombineChecked(shares [][]byte) ([]byte, error) {
+	shares = sortByIndex(shares)
+	secret, err := Combine(shares)
+	if err != nil {
+		return nil, err
+	}
+	info, err := parseFrame(shares[0])
+	if err != nil {
+		return nil, err
+	}
+	want := info.digest()
+	if want == nil {
+		return nil, ErrNoDigest
+	}
+	sum := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare(sum[:digestLen], want) != 1 {
+		wipe(secret)
+		return nil, ErrDigestMismatch
+	}
+	return secret, nil
+}
+
+// VerifySecretHash reports, in constant time, whether the SHA-256 of secret
+// equals expectedHash, a full 32-byte digest held separately from the shares.
+func VerifySecretHash(secret []byte, expectedHash []byte) bool {
+	sum := sha256.Sum256(secret)
+	return subtle.ConstantTimeCompare(sum[:], expectedHash) == 1
+}
+
+// CombineAndVerify reconstructs the secret and checks it against
+// expectedHash, the SHA-256 of the original secret. Unlike CombineChecked it
+// works with any shares, since the hash is supplied by the caller. A
+// mismatch fails with ErrDigestMismatch and the reconstruction is wiped.
+func CombineAndVerify(shares [][]byte, expectedHash []byte) ([]byte, error) {
+	secret, err := Combine(shares)
+	if err != nil {
+		return nil, err
+	}
+	if !VerifySecretHash(secret, expectedHash) {
+		wipe(secret)
+		return nil, ErrDigestMismatch
+	}
+	return secret, nil
+}
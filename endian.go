@@ -0,0 +1,72 @@
+// endian.go
+package shamir
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// DecodeShareEndian parses a share whose multi-byte header and tag fields
+// were framed in the given byte order and returns it in the canonical
+// big-endian form, verified. This is for exchanging shares with legacy
+// systems that wrote the length and CRC32 little-endian. Only those two
+// fields are affected: a SHA-256 or HMAC tag is a byte string in any order,
+// and v2 extension fields are always read big-endian.
+func DecodeShareEndian(buf []byte, order binary.ByteOrder) ([]byte, error) {
+	out := append([]byte(nil), buf...)
+	if order != binary.BigEndian {
+		if len(out) < headLen {
+			return nil, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+		}
+		binary.BigEndian.PutUint16(out[7:9], order.Uint16(buf[7:9]))
+		info, err := parseFrame(out)
+		if err != nil {
+			return nil, err
+		}
+		if info.integrity() == integrityCRC32 {
+			binary.BigEndian.PutUint32(info.tag, order.Uint32(info.tag))
+		}
+	}
+	if err := ValidateShare(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DetectByteOrder reports the byte order a share was framed in: big-endian
+// for canonical shares, little-endian for a share that only verifies once
+// its length and CRC are byte-swapped. A share that verifies in neither
+// order returns the canonical parse error, annotated when the share looks
+// byte-swapped (its length field only fits the data when swapped).
+func DetectByteOrder(buf []byte) (binary.ByteOrder, error) {
+	err := ValidateShare(buf)
+	if err == nil {
+		return binary.BigEndian, nil
+	}
+	if _, lerr := DecodeShareEndian(buf, binary.LittleEndian); lerr == nil {
+		return binary.LittleEndian, nil
+	}
+	if looksByteSwapped(buf) {
+		return nil, fmt.Errorf("%w (the length field looks byte-swapped; was it framed little-endian?)", err)
+	}
+	return nil, err
+}
+
+// looksByteSwapped reports whether buf's length field is inconsistent with
+// its size as written but consistent once swapped.
+func looksByteSwapped(buf []byte) bool {
+	if len(buf) < headLen {
+		return false
+	}
+	_, want, err := parseHeader(buf)
+	if err != nil || want == len(buf) {
+		return false
+	}
+	swapped := append([]byte(nil), buf[:headLen+1]...)
+	swapped[7], swapped[8] = buf[8], buf[7]
+	if len(buf) > headLen {
+		swapped[headLen] = buf[headLen]
+	}
+	_, want, err = parseHeader(swapped)
+	return err == nil && want == len(buf)
+}
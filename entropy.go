@@ -0,0 +1,97 @@
+// entropy.go
+package shamir
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// ErrLowEntropy is returned when an RNG's output fails an entropy check.
+var ErrLowEntropy = errors.New("shamir: RNG output does not look random")
+
+// EntropyChecker inspects a sample of RNG output and returns an error if it
+// looks non-random; see SplitWithReaderChecked.
+type EntropyChecker func(sample []byte) error
+
+// entropySampleLen is the number of bytes SplitWithReaderChecked draws from
+// the RNG for the check.
+const entropySampleLen = 2048
+
+// Rejection bounds for DefaultEntropyCheck. They are loose enough that a
+// good RNG fails less than about once in 10^9 samples.
+const (
+	monobitMaxSigma  = 6   // standard deviations of the 1-bit count
+	chiSquareMax     = 420 // 255 degrees of freedom
+	minEntropySample = 1024
+)
+
+// DefaultEntropyCheck is a cheap sanity check on RNG output: a monobit test
+// (the share of 1 bits), and chi-square tests on the byte values and on the
+// differences between successive bytes, which catch constant, biased and
+// counting readers. It is a heuristic that catches a stuck or misconfigured
+// source; passing it proves nothing about cryptographic quality.
+func DefaultEntropyCheck(sample []byte) error {
+	if len(sample) < minEntropySample {
+		return fmt.Errorf("%w: sample of %d bytes is too small", ErrInvalidParams, len(sample))
+	}
+	ones := 0
+	for _, b := range sample {
+		ones += bits.OnesCount8(b)
+	}
+	nbits := float64(len(sample) * 8)
+	if dev := math.Abs(float64(ones) - nbits/2); dev > monobitMaxSigma*math.Sqrt(nbits)/2 {
+		return fmt.Errorf("%w: %d of %d bits set", ErrLowEntropy, ones, len(sample)*8)
+	}
+	var values, diffs [256]int
+	for i, b := range sample {
+		values[b]++
+		if i > 0 {
+			diffs[b-sample[i-1]]++
+		}
+	}
+	if chi := chiSquare(values[:], len(sample)); chi > chiSquareMax {
+		return fmt.Errorf("%w: byte distribution chi-square %.0f", ErrLowEntropy, chi)
+	}
+	if chi := chiSquare(diffs[:], len(sample)-1); chi > chiSquareMax {
+		return fmt.Errorf("%w: successive-byte chi-square %.0f", ErrLowEntropy, chi)
+	}
+	return nil
+}
+
+// chiSquare returns the chi-square statistic of counts against a uniform
+// distribution of total observations.
+func chiSquare(counts []int, total int) float64 {
+	expected := float64(total) / float64(len(counts))
+	var chi float64
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	return chi
+}
+
+// SplitWithReaderChecked is SplitWithReader that first draws a sample from
+// rng and runs check on it, refusing to split if the RNG looks broken (a
+// stuck hardware generator, a misconfigured test reader left in production).
+// check may be nil to use DefaultEntropyCheck. The sample is discarded and
+// wiped; coefficients are drawn from fresh output.
+func SplitWithReaderChecked(rng io.Reader, secret []byte, t, n int, check EntropyChecker) ([][]byte, error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, err
+	}
+	if check == nil {
+		check = DefaultEntropyCheck
+	}
+	sample := make([]byte, entropySampleLen)
+	defer wipe(sample)
+	if _, err := io.ReadFull(rng, sample); err != nil {
+		return nil, fmt.Errorf("shamir: read entropy sample: %w", err)
+	}
+	if err := check(sample); err != nil {
+		return nil, err
+	}
+	return split(rng, secret, t, n)
+}
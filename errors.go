@@ -0,0 +1,21 @@
+// errors.go
+package shamir
+
+import "errors"
+
+// Sentinel errors returned (possibly wrapped with context) by the package.
+// Match them with errors.Is.
+var (
+	ErrInvalidParams      = errors.New("shamir: invalid parameters")
+	ErrBadMagic           = errors.New("shamir: bad magic header")
+	ErrVersionMismatch    = errors.New("shamir: version mismatch")
+	ErrCRCMismatch        = errors.New("shamir: CRC32 mismatch")
+	ErrInsufficientShares = errors.New("shamir: insufficient shares")
+	ErrDuplicateIndex     = errors.New("shamir: duplicate index")
+	ErrConflictingIndex   = errors.New("shamir: conflicting shares for index")
+	ErrInvalidIndex       = errors.New("shamir: invalid index")
+	ErrLengthMismatch     = errors.New("shamir: share length mismatch")
+	ErrHeaderMismatch     = errors.New("shamir: inconsistent header fields")
+	ErrInconsistentShares = errors.New("shamir: shares do not lie on one polynomial")
+	ErrSecretTooLarge     = errors.New("shamir: secret exceeds MaxSecretLen")
+)
@@ -0,0 +1,104 @@
+// errors_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// edited returns a copy of share changed by edit and, if reseal is set,
+// with its CRC recomputed so only the edit is wrong.
+func edited(share []byte, reseal bool, edit func([]byte) []byte) []byte {
+	s := edit(bytes.Clone(share))
+	if reseal {
+		sealShare(s, nil)
+	}
+	return s
+}
+
+func TestValidationErrors(t *testing.T) {
+	shares, err := Split([]byte("sentinel errors"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s0, s1 := shares[0], shares[1]
+	set := func(i int, v byte) func([]byte) []byte {
+		return func(b []byte) []byte { b[i] = v; return b }
+	}
+	badMagic := edited(s1, false, set(0, 'X'))
+	badVersion := edited(s1, false, set(4, 9))
+	truncated := edited(s1, false, func(b []byte) []byte { return b[:len(b)-1] })
+	tooShort := s1[:5]
+	badCRC := edited(s1, false, func(b []byte) []byte { b[headLen] ^= 1; return b })
+	index0 := edited(s1, true, set(9, 0))
+	otherTotal := edited(s1, true, set(6, 4))
+	otherThreshold := edited(s1, true, set(5, 3))
+
+	tests := []struct {
+		name     string
+		shares   [][]byte
+		wantErr  error
+		validate bool // the second share also fails ValidateShare
+	}{
+		{"bad magic", [][]byte{s0, badMagic}, ErrBadMagic, true},
+		{"bad magic first", [][]byte{badMagic, s0}, ErrBadMagic, true},
+		{"unsupported version", [][]byte{s0, badVersion}, ErrVersionMismatch, true},
+		{"truncated", [][]byte{s0, truncated}, ErrLengthMismatch, true},
+		{"too short for a header", [][]byte{s0, tooShort}, ErrLengthMismatch, true},
+		{"CRC", [][]byte{s0, badCRC}, ErrCRCMismatch, true},
+		{"index 0", [][]byte{s0, index0}, ErrInvalidIndex, true},
+		{"duplicate index", [][]byte{s0, s0}, ErrDuplicateIndex, false},
+		{"total mismatch", [][]byte{s0, otherTotal}, ErrHeaderMismatch, false},
+		{"threshold mismatch", [][]byte{s0, otherThreshold}, ErrHeaderMismatch, false},
+		{"one share", [][]byte{s0}, ErrInsufficientShares, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Combine(tt.shares); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Combine error = %v, want %v", err, tt.wantErr)
+			}
+			if !tt.validate {
+				return
+			}
+			bad := tt.shares[1]
+			if bytes.Equal(bad, s0) {
+				bad = tt.shares[0]
+			}
+			if err := ValidateShare(bad); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateShare error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitAndStorageErrors(t *testing.T) {
+	shares, err := Split([]byte("x"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := newMapStorage()
+	if err := StoreShares(shares, st); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		run     func() error
+		wantErr error
+	}{
+		{"threshold 1", func() error { _, err := Split([]byte("x"), 1, 3); return err }, ErrInvalidParams},
+		{"threshold above total", func() error { _, err := Split([]byte("x"), 4, 3); return err }, ErrInvalidParams},
+		{"too many shares", func() error { _, err := Split([]byte("x"), 2, 256); return err }, ErrInvalidParams},
+		{"secret over 65535 bytes", func() error { _, err := Split(make([]byte, 1<<16), 2, 3); return err }, ErrInvalidParams},
+		{"store short share", func() error { return StoreShares([][]byte{{1, 2}}, newMapStorage()) }, ErrLengthMismatch},
+		{"authorize below threshold", func() error { _, err := MultiPartyAuthorize(st, []byte{1}, 2); return err }, ErrInsufficientShares},
+		{"combine from storage", func() error { _, err := CombineFromStorage(newMapStorage(), 2); return err }, ErrInsufficientShares},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
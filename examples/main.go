@@ -26,7 +26,9 @@ func splitCombine() {
 	}
 	fmt.Printf("Generated %d shares (threshold %d):\n", total, threshold)
 	for _, s := range shares {
-		fmt.Printf(" • #%d → %x\n", s[9], s[10:]) // skip header bytes
+		idx, _ := shamir.ShareIndex(s)
+		payload, _ := shamir.SharePayload(s)
+		fmt.Printf(" • #%d → %x\n", idx, payload)
 	}
 
 	recovered, err := shamir.Combine(shares)
@@ -55,7 +57,7 @@ func splitStorage() {
 
 	// Assign alternating backends
 	for i, s := range shares {
-		idx := s[9]
+		idx, _ := shamir.ShareIndex(s)
 		if i%2 == 0 {
 			ms.AssignStorage(idx, mem)
 		} else {
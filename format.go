@@ -0,0 +1,313 @@
+// format.go
+package shamir
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Share layout.
+//
+// v1: magic(4) ver(1)=1 thr(1) tot(1) len(2) idx(1) | payload(len) | crc32(4)
+//
+// v2 keeps the first 10 bytes identical and appends a flags byte followed by
+// the extension fields selected by the flags, in flag-bit order. The
+// integrity bits of the flags select the trailing tag:
+//
+// v2: magic(4) ver(1)=2 thr(1) tot(1) len(2) idx(1) flags(1) | ext | payload(len) | tag
+const (
+	versionV2 = 2
+
+	// flagDigest: ext carries an 8-byte truncated SHA-256 of the secret.
+	flagDigest byte = 1 << 0
+
+	// integrityMask selects how the trailing tag is computed.
+	integrityMask   byte = 3 << 1
+	integrityCRC32  byte = 0 << 1 // 4-byte CRC32, as in v1
+	integritySHA256 byte = 1 << 1 // 32-byte SHA-256
+	integrityHMAC   byte = 2 << 1 // 32-byte HMAC-SHA256 keyed by the AAD
+
+	// flagCompressed: the payload is the gzip-compressed secret.
+	flagCompressed byte = 1 << 3
+
+	// flagMeta: ext carries an 8-byte unix creation time and a 4-byte
+	// scheme ID, both big-endian, after the digest if there is one.
+	flagMeta byte = 1 << 4
+
+	// flagRecovery: the share belongs to a break-glass recovery set (see
+	// SplitWithRecovery). It carries no ext field; since sameSplit compares
+	// flags, recovery shares never combine with primary shares.
+	flagRecovery byte = 1 << 5
+
+	digestLen = 8
+	metaLen   = 12
+
+	knownFlags = flagDigest | integrityMask | flagCompressed | flagMeta | flagRecovery
+)
+
+var (
+	// ErrAADRequired is returned when a share bound to AAD is combined without it.
+	ErrAADRequired = errors.New("shamir: share is bound to associated data")
+	// ErrAADMismatch is returned when a share's HMAC doesn't verify under the given AAD.
+	ErrAADMismatch = errors.New("shamir: associated data mismatch or corrupted share")
+)
+
+// frame describes everything in a share's header other than the index.
+type frame struct {
+	version byte
+	flags   byte
+	ext     []byte // extension fields that follow the flags byte (v2 only)
+}
+
+// v1Frame is the frame produced by Split.
+var v1Frame = frame{version: version}
+
+// headerLen returns the number of bytes preceding the payload.
+func (f frame) headerLen() int {
+	if f.version == version {
+		return headLen
+	}
+	return headLen + 1 + len(f.ext)
+}
+
+// integrity returns the integrity mode of the frame.
+func (f frame) integrity() byte {
+	if f.version == version {
+		return integrityCRC32
+	}
+	return f.flags & integrityMask
+}
+
+// tagLen returns the size of the trailing integrity tag.
+func (f frame) tagLen() int {
+	if f.integrity() == integrityCRC32 {
+		return 4
+	}
+	return sha256.Size
+}
+
+// extLen returns the size of the extension fields selected by flags.
+func extLen(flags byte) int {
+	n := 0
+	if flags&flagDigest != 0 {
+		n += digestLen
+	}
+	if flags&flagMeta != 0 {
+		n += metaLen
+	}
+	return n
+}
+
+// validFlags reports whether every bit and mode in flags is understood.
+func validFlags(flags byte) bool {
+	if flags&^knownFlags != 0 {
+		return false
+	}
+	switch flags & integrityMask {
+	case integrityCRC32, integritySHA256, integrityHMAC:
+		return true
+	}
+	return false
+}
+
+// shareInfo is a parsed view of a raw share. Slices alias the share buffer.
+type shareInfo struct {
+	frame
+	threshold byte
+	total     byte
+	index     byte
+	payload   []byte
+	body      []byte // everything covered by the tag
+	tag       []byte
+}
+
+// digest returns the embedded secret digest, or nil if there is none.
+func (s shareInfo) digest() []byte {
+	if s.flags&flagDigest == 0 {
+		return nil
+	}
+	return s.ext[:digestLen]
+}
+
+// meta returns the embedded creation-time and scheme-ID fields, or nil if
+// there are none.
+func (s shareInfo) meta() []byte {
+	if s.flags&flagMeta == 0 {
+		return nil
+	}
+	return s.ext[len(s.ext)-metaLen:]
+}
+
+// parseFrame checks the structure of a share (magic, version, flags and
+// lengths) without verifying its integrity tag.
+func parseFrame(buf []byte) (shareInfo, error) {
+	s, want, err := parseHeader(buf)
+	if err != nil {
+		return s, err
+	}
+	if len(buf) != want {
+		return s, fmt.Errorf("%w: share %d", ErrLengthMismatch, s.index)
+	}
+	end := want - s.tagLen()
+	s.payload = buf[s.headerLen():end]
+	s.body = buf[:end]
+	s.tag = buf[end:]
+	return s, nil
+}
+
+// parseHeader parses the header fields of a share and returns the total
+// length the header declares. The payload and tag are left unset.
+func parseHeader(buf []byte) (shareInfo, int, error) {
+	var s shareInfo
+	if len(buf) < headLen {
+		return s, 0, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+	}
+	if string(buf[0:4]) != magicHeader {
+		return s, 0, ErrBadMagic
+	}
+	s.version = buf[4]
+	s.threshold = buf[5]
+	s.total = buf[6]
+	s.index = buf[9]
+	secretLen := int(binary.BigEndian.Uint16(buf[7:9]))
+	if secretLen > MaxSecretLen {
+		return s, 0, fmt.Errorf("%w: share declares %d bytes", ErrSecretTooLarge, secretLen)
+	}
+	switch s.version {
+	case version:
+	case versionV2:
+		if len(buf) < headLen+1 {
+			return s, 0, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+		}
+		s.flags = buf[headLen]
+		if !validFlags(s.flags) {
+			return s, 0, fmt.Errorf("%w: unknown flags %#x", ErrVersionMismatch, s.flags)
+		}
+		n := extLen(s.flags)
+		if len(buf) < headLen+1+n {
+			return s, 0, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+		}
+		s.ext = buf[headLen+1 : headLen+1+n]
+	default:
+		return s, 0, fmt.Errorf("%w: unsupported version %d", ErrVersionMismatch, s.version)
+	}
+	return s, s.headerLen() + secretLen + s.tagLen(), nil
+}
+
+// parseShare parses a share and verifies its integrity tag and index.
+// Shares bound to associated data fail with ErrAADRequired.
+func parseShare(buf []byte) (shareInfo, error) {
+	return parseShareAAD(buf, nil)
+}
+
+// parseShareAAD is parseShare for shares that may be bound to aad. A nil aad
+// means none was supplied. Each share is verified according to its own
+// integrity mode, so a set may mix modes.
+func parseShareAAD(buf []byte, aad []byte) (shareInfo, error) {
+	s, err := parseFrame(buf)
+	if err != nil {
+		return s, err
+	}
+	if s.index == 0 {
+		return s, fmt.Errorf("%w: index 0", ErrInvalidIndex)
+	}
+	switch s.integrity() {
+	case integrityHMAC:
+		if aad == nil {
+			return s, fmt.Errorf("%w: share %d", ErrAADRequired, s.index)
+		}
+		if !hmac.Equal(s.tag, hmacTag(s.body, aad)) {
+			return s, fmt.Errorf("%w: share %d", ErrAADMismatch, s.index)
+		}
+	case integritySHA256:
+		sum := sha256.Sum256(s.body)
+		if !hmac.Equal(s.tag, sum[:]) {
+			return s, fmt.Errorf("%w: share %d (SHA-256 tag)", ErrCRCMismatch, s.index)
+		}
+	default:
+		if crc32.ChecksumIEEE(s.body) != binary.BigEndian.Uint32(s.tag) {
+			return s, fmt.Errorf("%w: share %d", ErrCRCMismatch, s.index)
+		}
+	}
+	return s, nil
+}
+
+// sameSplit reports whether two shares can be interpolated together: the
+// fields that matter for reconstruction agree. The format version and the
+// integrity mode may differ, since each share's tag is verified on its own.
+// The threshold byte is left to callers, as CombineWithThreshold ignores it.
+func sameSplit(a, b shareInfo) bool {
+	return a.total == b.total && len(a.payload) == len(b.payload) &&
+		a.flags&^integrityMask == b.flags&^integrityMask && bytes.Equal(a.ext, b.ext)
+}
+
+// hmacTag computes the HMAC-SHA256 of body keyed by aad.
+func hmacTag(body, aad []byte) []byte {
+	m := hmac.New(sha256.New, aad)
+	m.Write(body)
+	return m.Sum(nil)
+}
+
+// newShare frames a payload and seals it with a CRC32.
+func newShare(f frame, t, n, index byte, payload []byte) []byte {
+	hl := f.headerLen()
+	buf := make([]byte, hl+len(payload)+f.tagLen())
+	writeHeader(buf, f, t, n, index, len(payload))
+	copy(buf[hl:], payload)
+	sealShare(buf, nil)
+	return buf
+}
+
+// writeHeader writes the frame header into buf.
+func writeHeader(buf []byte, f frame, t, n, index byte, payloadLen int) {
+	copy(buf[0:], magicHeader)
+	buf[4] = f.version
+	buf[5] = t
+	buf[6] = n
+	binary.BigEndian.PutUint16(buf[7:], uint16(payloadLen))
+	buf[9] = index
+	if f.version != version {
+		buf[headLen] = f.flags
+		copy(buf[headLen+1:], f.ext)
+	}
+}
+
+// sealShare recomputes the trailing integrity tag of a framed share. aad is
+// only used by shares in HMAC mode.
+func sealShare(buf []byte, aad []byte) {
+	f := v1Frame
+	if buf[4] != version {
+		f = frame{version: buf[4], flags: buf[headLen]}
+	}
+	end := len(buf) - f.tagLen()
+	switch f.integrity() {
+	case integrityHMAC:
+		copy(buf[end:], hmacTag(buf[:end], aad))
+		return
+	case integritySHA256:
+		sum := sha256.Sum256(buf[:end])
+		copy(buf[end:], sum[:])
+		return
+	}
+	binary.BigEndian.PutUint32(buf[end:], crc32.ChecksumIEEE(buf[:end]))
+}
+
+// ValidateShare checks a share on receipt, before it is ever combined: the
+// magic, version and flags, that the length matches the header, that the
+// index is non-zero, and the CRC32 or SHA-256 tag. Failures wrap
+// ErrLengthMismatch, ErrBadMagic, ErrVersionMismatch, ErrInvalidIndex or
+// ErrCRCMismatch. These are the same checks Combine applies to every share.
+// A share bound to AAD has its framing checked but not its tag, which needs
+// the associated data; CombineWithAAD verifies it.
+func ValidateShare(share []byte) error {
+	_, err := parseShare(share)
+	if errors.Is(err, ErrAADRequired) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+// format_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// reframe re-encodes a v1 share's payload under frame f, keeping its
+// threshold, total and index.
+func reframe(t *testing.T, share []byte, f frame) []byte {
+	t.Helper()
+	info, err := parseShare(share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newShare(f, info.threshold, info.total, info.index, info.payload)
+}
+
+func TestCombineMixedVersions(t *testing.T) {
+	secret := []byte("mixed versions")
+	shares, err := Split(secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := frame{version: versionV2, flags: flagDigest, ext: make([]byte, digestLen)}
+	unknown := bytes.Clone(shares[1])
+	unknown[4] = 3
+
+	tests := []struct {
+		name    string
+		second  []byte
+		wantErr error // nil means the pair combines to secret
+	}{
+		{"v1 and v1", shares[1], nil},
+		{"v1 and v2 CRC32", reframe(t, shares[1], frame{version: versionV2}), nil},
+		{"v1 and v2 SHA-256", reframe(t, shares[1], frame{version: versionV2, flags: integritySHA256}), nil},
+		{"v1 and v2 with digest", reframe(t, shares[1], digest), ErrHeaderMismatch},
+		{"v1 and v2 compressed", reframe(t, shares[1], frame{version: versionV2, flags: flagCompressed}), ErrHeaderMismatch},
+		{"v1 and v2 recovery", reframe(t, shares[1], frame{version: versionV2, flags: flagRecovery}), ErrHeaderMismatch},
+		{"v1 and unknown version", unknown, ErrVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, pair := range [][][]byte{{shares[0], tt.second}, {tt.second, shares[2]}} {
+				got, err := Combine(pair)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Combine error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr == nil && !bytes.Equal(got, secret) {
+					t.Fatalf("Combine = %q, want %q", got, secret)
+				}
+			}
+		})
+	}
+}
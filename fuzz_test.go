@@ -0,0 +1,98 @@
+// fuzz_test.go
+package shamir
+
+import (
+	"bytes"
+	"testing"
+)
+
+// fuzzSeeds returns well-formed shares in every framing, for the seed corpus.
+func fuzzSeeds(f *testing.F) [][]byte {
+	f.Helper()
+	secret := []byte("fuzz seed secret")
+	var seeds [][]byte
+	for _, opts := range []SplitOptions{
+		{},
+		{EmbedDigest: true},
+		{SHA256Tag: true},
+		{SchemeID: 9},
+		{Compress: true},
+		{AAD: []byte("aad")},
+	} {
+		shares, err := SplitWithOptions(secret, 2, 3, opts)
+		if err != nil {
+			f.Fatal(err)
+		}
+		seeds = append(seeds, shares...)
+	}
+	return seeds
+}
+
+// FuzzCombine feeds arbitrary pairs of shares to Combine and the envelope
+// encoders. None of them may panic, and a pair that combines must survive a
+// round trip through ToJSON and FromJSON.
+func FuzzCombine(f *testing.F) {
+	seeds := fuzzSeeds(f)
+	for i := 0; i+1 < len(seeds); i++ {
+		f.Add(seeds[i], seeds[i+1])
+	}
+	f.Add([]byte("SHAM\x01\x02\x03\xff\xff\x01"), []byte("SHAM\x01\x02\x03\xff\xff\x02"))
+	f.Add([]byte("SHAM\x01\x01\x01\x00\x00\x01"), []byte{})
+	f.Add([]byte("SHAM\x02\x02\x03\x00\x01\x01\xff"), []byte("SHAM"))
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		secret, err := Combine([][]byte{a, b})
+		if err != nil {
+			return
+		}
+		ja, err := ToJSON(a)
+		if err != nil {
+			t.Fatalf("ToJSON of a combinable share: %v", err)
+		}
+		jb, err := ToJSON(b)
+		if err != nil {
+			t.Fatalf("ToJSON of a combinable share: %v", err)
+		}
+		ra, err := FromJSON(ja)
+		if err != nil {
+			t.Fatalf("FromJSON(ToJSON(a)): %v", err)
+		}
+		rb, err := FromJSON(jb)
+		if err != nil {
+			t.Fatalf("FromJSON(ToJSON(b)): %v", err)
+		}
+		again, err := Combine([][]byte{ra, rb})
+		if err != nil || !bytes.Equal(again, secret) {
+			t.Fatalf("Combine after JSON round trip = %x, %v; want %x", again, err, secret)
+		}
+	})
+}
+
+// FuzzFromJSON feeds arbitrary text to FromJSON, which must not panic, and
+// checks that anything it accepts re-encodes to the same share.
+func FuzzFromJSON(f *testing.F) {
+	for _, s := range fuzzSeeds(f) {
+		js, err := ToJSON(s)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(js)
+	}
+	f.Add(`{}`)
+	f.Add(`{"index":1,"threshold":2,"total_shares":3,"data":""}`)
+	f.Add(`{"index":0,"threshold":0,"total_shares":0,"data":"AA==","version":2,"flags":255}`)
+	f.Add(`{"index":1,"threshold":2,"total_shares":3,"data":"!!","version":9}`)
+	f.Fuzz(func(t *testing.T, js string) {
+		share, err := FromJSON(js)
+		if err != nil {
+			return
+		}
+		again, err := ToJSON(share)
+		if err != nil {
+			t.Fatalf("ToJSON of a share FromJSON built: %v", err)
+		}
+		share2, err := FromJSON(again)
+		if err != nil || !bytes.Equal(share2, share) {
+			t.Fatalf("round trip = %x, %v; want %x", share2, err, share)
+		}
+	})
+}
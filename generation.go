@@ -0,0 +1,76 @@
+// generation.go
+package shamir
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Generation identifies the split a share came from, as far as its header
+// tells: shares of one generation can be combined together. SchemeID is
+// only meaningful when HasMeta is set (see SplitOptions.SchemeID).
+type Generation struct {
+	SecretLen int
+	Threshold byte
+	SchemeID  uint32
+	HasMeta   bool
+}
+
+// String formats the generation for logs.
+func (g Generation) String() string {
+	if g.HasMeta {
+		return fmt.Sprintf("scheme %d, %d-of-n, %d bytes", g.SchemeID, g.Threshold, g.SecretLen)
+	}
+	return fmt.Sprintf("%d-of-n, %d bytes", g.Threshold, g.SecretLen)
+}
+
+// Generation returns the generation the described share belongs to.
+func (m ShareMetadata) Generation() Generation {
+	return Generation{SecretLen: m.SecretLen, Threshold: m.Threshold, SchemeID: m.SchemeID, HasMeta: m.HasMeta}
+}
+
+// GroupGenerations sorts shares into generations, e.g. when shares were
+// captured on both sides of a rotation that changed the secret length or
+// scheme. Shares that fail InspectShares' checks are left out.
+func GroupGenerations(shares [][]byte) map[Generation][][]byte {
+	groups := make(map[Generation][][]byte)
+	for i, m := range InspectShares(shares) {
+		if m.Err != nil {
+			continue
+		}
+		g := m.Generation()
+		groups[g] = append(groups[g], shares[i])
+	}
+	return groups
+}
+
+// CombineAny reconstructs every generation present in shares that has
+// enough shares, returning one secret per generation. Generations that can't
+// be reconstructed (too few shares, conflicting copies of an index) are
+// left out of the map and their errors joined into the returned error, so a
+// non-nil error does not mean the map is empty. Invalid shares are skipped.
+// Nothing ties generations to an order: use SchemeID or the embedded
+// creation time to decide which secret is current.
+func CombineAny(shares [][]byte) (map[Generation][]byte, error) {
+	groups := GroupGenerations(shares)
+	gens := make([]Generation, 0, len(groups))
+	for g := range groups {
+		gens = append(gens, g)
+	}
+	sort.Slice(gens, func(i, j int) bool { return gens[i].String() < gens[j].String() })
+	out := make(map[Generation][]byte, len(groups))
+	var errs []error
+	for _, g := range gens {
+		secret, err := Combine(groups[g])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("generation %s: %w", g, err))
+			continue
+		}
+		out[g] = secret
+	}
+	if len(out) == 0 && len(errs) == 0 {
+		return out, fmt.Errorf("%w: no valid shares", ErrInsufficientShares)
+	}
+	return out, errors.Join(errs...)
+}
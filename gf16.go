@@ -0,0 +1,184 @@
+// gf16.go
+package shamir
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/oarkflow/shamir/gf16"
+)
+
+// 16-bit share layout. The field is GF(2^16), so indices, threshold and
+// total are 2 bytes wide and the payload is a sequence of big-endian 16-bit
+// symbols (an odd-length secret gets one zero byte of padding):
+//
+// magic(4) ver(1)=0x10 thr(2) tot(2) len(2) idx(2) | payload | crc32(4)
+//
+// These shares are not interchangeable with GF(256) shares; Combine rejects
+// them as an unsupported version.
+const (
+	versionGF16 = 0x10
+	headLenGF16 = 13
+)
+
+// SplitGF16 splits the secret into n shares requiring t to reconstruct,
+// computing over GF(2^16) so that n may be as large as 65535.
+//
+// It is considerably slower than Split. Each 16-bit field operation is a
+// lookup into tables of several hundred KiB instead of 768 bytes, and the
+// work grows with n·t per symbol, so splitting a 32-byte secret 500-of-1000
+// takes around a tenth of a second. Use Split whenever 255 shares
+// are enough.
+func SplitGF16(secret []byte, t, n int) ([][]byte, error) {
+	return SplitGF16WithReader(rand.Reader, secret, t, n)
+}
+
+// SplitGF16WithReader is SplitGF16 with a custom RNG (for testing).
+func SplitGF16WithReader(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
+	if t < 2 || t > gf16.Order {
+		return nil, fmt.Errorf("%w: threshold must be between 2 and %d", ErrInvalidParams, gf16.Order)
+	}
+	if n < t || n > gf16.Order {
+		return nil, fmt.Errorf("%w: number of shares must be between threshold and %d", ErrInvalidParams, gf16.Order)
+	}
+	if len(secret) > 0xFFFF {
+		return nil, fmt.Errorf("%w: secret longer than 65535 bytes", ErrInvalidParams)
+	}
+	symbols := (len(secret) + 1) / 2
+	payloadLen := 2 * symbols
+	shares := make([][]byte, n)
+	for i := range shares {
+		buf := make([]byte, headLenGF16+payloadLen+4)
+		copy(buf, magicHeader)
+		buf[4] = versionGF16
+		binary.BigEndian.PutUint16(buf[5:], uint16(t))
+		binary.BigEndian.PutUint16(buf[7:], uint16(n))
+		binary.BigEndian.PutUint16(buf[9:], uint16(len(secret)))
+		binary.BigEndian.PutUint16(buf[11:], uint16(i+1))
+		shares[i] = buf
+	}
+	coeffs := make([]byte, 2*(t-1))
+	defer wipe(coeffs)
+	for j := 0; j < symbols; j++ {
+		var s uint16
+		if 2*j+1 < len(secret) {
+			s = binary.BigEndian.Uint16(secret[2*j:])
+		} else {
+			s = uint16(secret[2*j]) << 8
+		}
+		if _, err := io.ReadFull(rng, coeffs); err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			x := uint16(i + 1)
+			// Horner's rule from the highest coefficient down to the secret
+			var y uint16
+			for k := t - 2; k >= 0; k-- {
+				y = gf16.Mul(y^binary.BigEndian.Uint16(coeffs[2*k:]), x)
+			}
+			binary.BigEndian.PutUint16(shares[i][headLenGF16+2*j:], y^s)
+		}
+	}
+	for _, buf := range shares {
+		end := len(buf) - 4
+		binary.BigEndian.PutUint32(buf[end:], crc32.ChecksumIEEE(buf[:end]))
+	}
+	return shares, nil
+}
+
+// CombineGF16 reconstructs a secret from shares produced by SplitGF16.
+// Shares may be given in any order; when more than the threshold are given,
+// the first threshold are used.
+func CombineGF16(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 shares", ErrInsufficientShares)
+	}
+	var t, total, secretLen int
+	var xs []uint16
+	var data [][]byte
+	seen := make(map[uint16]bool)
+	for i, buf := range shares {
+		if len(buf) < headLenGF16+4 {
+			return nil, fmt.Errorf("share %d: %w: share too short", i, ErrLengthMismatch)
+		}
+		if string(buf[0:4]) != magicHeader {
+			return nil, fmt.Errorf("share %d: %w", i, ErrBadMagic)
+		}
+		if buf[4] != versionGF16 {
+			return nil, fmt.Errorf("share %d: %w: not a 16-bit share (version %d)", i, ErrVersionMismatch, buf[4])
+		}
+		st := int(binary.BigEndian.Uint16(buf[5:]))
+		sn := int(binary.BigEndian.Uint16(buf[7:]))
+		sl := int(binary.BigEndian.Uint16(buf[9:]))
+		x := binary.BigEndian.Uint16(buf[11:])
+		if len(buf) != headLenGF16+2*((sl+1)/2)+4 {
+			return nil, fmt.Errorf("%w: share %d", ErrLengthMismatch, x)
+		}
+		end := len(buf) - 4
+		if crc32.ChecksumIEEE(buf[:end]) != binary.BigEndian.Uint32(buf[end:]) {
+			return nil, fmt.Errorf("%w: share %d", ErrCRCMismatch, x)
+		}
+		if x == 0 {
+			return nil, fmt.Errorf("%w: index 0", ErrInvalidIndex)
+		}
+		if i == 0 {
+			t, total, secretLen = st, sn, sl
+			if t < 2 {
+				// a corrupt header would otherwise "reconstruct" from 1 share,
+				// or interpolate every share given for threshold 0
+				return nil, fmt.Errorf("%w: threshold %d", ErrInvalidParams, t)
+			}
+			if len(shares) < t {
+				return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(shares), t)
+			}
+			xs = make([]uint16, 0, t)
+			data = make([][]byte, 0, t)
+		} else if st != t || sn != total || sl != secretLen {
+			return nil, fmt.Errorf("%w: share %d", ErrHeaderMismatch, x)
+		}
+		if seen[x] {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateIndex, x)
+		}
+		seen[x] = true
+		xs = append(xs, x)
+		data = append(data, buf[headLenGF16:end])
+		if len(xs) == t {
+			break
+		}
+	}
+	weights := lagrangeGF16(xs)
+	out := make([]byte, len(data[0]))
+	for j := 0; j < len(out); j += 2 {
+		var s uint16
+		for i, w := range weights {
+			s ^= gf16.Mul(w, binary.BigEndian.Uint16(data[i][j:]))
+		}
+		binary.BigEndian.PutUint16(out[j:], s)
+	}
+	return out[:secretLen], nil
+}
+
+// lagrangeGF16 computes the Lagrange basis weights at x=0 for distinct
+// non-zero xs: w_i = prod_{j != i} x_j / (x_j - x_i).
+func lagrangeGF16(xs []uint16) []uint16 {
+	prodAll := uint16(1)
+	for _, x := range xs {
+		prodAll = gf16.Mul(prodAll, x)
+	}
+	w := make([]uint16, len(xs))
+	for i, xi := range xs {
+		d := xi
+		for j, xj := range xs {
+			if i != j {
+				d = gf16.Mul(d, xi^xj)
+			}
+		}
+		// xs are distinct and non-zero, so d != 0
+		id, _ := gf16.Inv(d)
+		w[i] = gf16.Mul(prodAll, id)
+	}
+	return w
+}
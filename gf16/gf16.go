@@ -0,0 +1,68 @@
+// gf16/gf16.go
+
+// Package gf16 implements arithmetic in GF(2^16) with the primitive
+// polynomial x^16 + x^12 + x^3 + x + 1 (0x1100B). It backs the 16-bit share
+// format (shamir.SplitGF16), which allows up to 65535 shares.
+//
+// Elements are uint16 values. Multiplication uses exp/log tables occupying
+// about 384 KiB, built when the package is initialised.
+package gf16
+
+import "errors"
+
+// Poly is the reduction polynomial, including the x^16 term.
+const Poly = 0x1100B
+
+// Order is the number of non-zero field elements.
+const Order = 65535
+
+var (
+	expTable [2 * Order]uint16
+	logTable [Order + 1]uint16
+)
+
+func init() {
+	x := uint32(1)
+	for i := 0; i < Order; i++ {
+		expTable[i] = uint16(x)
+		logTable[x] = uint16(i)
+		x <<= 1 // generator is x
+		if x&0x10000 != 0 {
+			x ^= Poly
+		}
+	}
+	// duplicate to avoid mod operations
+	for i := Order; i < 2*Order; i++ {
+		expTable[i] = expTable[i-Order]
+	}
+}
+
+// Add returns a + b (and a - b, which is the same in characteristic 2).
+func Add(a, b uint16) uint16 {
+	return a ^ b
+}
+
+// Mul returns a * b.
+func Mul(a, b uint16) uint16 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+// Inv returns the multiplicative inverse of a.
+func Inv(a uint16) (uint16, error) {
+	if a == 0 {
+		return 0, errors.New("gf16: inverse of zero")
+	}
+	return expTable[Order-int(logTable[a])], nil
+}
+
+// Div returns a / b.
+func Div(a, b uint16) (uint16, error) {
+	ib, err := Inv(b)
+	if err != nil {
+		return 0, err
+	}
+	return Mul(a, ib), nil
+}
@@ -0,0 +1,49 @@
+// gf16/gf16_test.go
+package gf16
+
+import "testing"
+
+func TestFieldAxioms(t *testing.T) {
+	// sample the field with a stride coprime to its order
+	for a := uint32(1); a <= Order; a += 97 {
+		x := uint16(a)
+		inv, err := Inv(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Mul(x, inv); got != 1 {
+			t.Fatalf("%d * Inv(%d) = %d, want 1", x, x, got)
+		}
+		for _, y := range []uint16{1, 2, 0x8000, 0xFFFF, uint16(a * 31)} {
+			if Mul(x, y) != Mul(y, x) {
+				t.Fatalf("Mul(%d, %d) is not commutative", x, y)
+			}
+			if y == 0 {
+				continue
+			}
+			q, err := Div(Mul(x, y), y)
+			if err != nil || q != x {
+				t.Fatalf("Div(Mul(%d, %d), %d) = %d, %v", x, y, y, q, err)
+			}
+		}
+		if Mul(x, 0) != 0 || Add(x, x) != 0 {
+			t.Fatalf("zero laws fail for %d", x)
+		}
+	}
+}
+
+func TestReduction(t *testing.T) {
+	// x^15 * x = x^16 = x^12 + x^3 + x + 1 modulo Poly
+	if got, want := Mul(0x8000, 2), uint16(Poly&0xFFFF); got != want {
+		t.Fatalf("Mul(x^15, x) = %#x, want %#x", got, want)
+	}
+}
+
+func TestInverseOfZero(t *testing.T) {
+	if _, err := Inv(0); err == nil {
+		t.Fatal("Inv(0) succeeded")
+	}
+	if _, err := Div(1, 0); err == nil {
+		t.Fatal("Div(1, 0) succeeded")
+	}
+}
@@ -0,0 +1,84 @@
+// gf16_test.go
+package shamir
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"testing"
+)
+
+func TestGF16RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret []byte
+		t, n   int
+		pick   func(shares [][]byte) [][]byte
+	}{
+		{"2 of 3", []byte("hello"), 2, 3, func(s [][]byte) [][]byte { return s[1:] }},
+		{"odd length", []byte("odd"), 3, 5, func(s [][]byte) [][]byte { return [][]byte{s[4], s[0], s[2]} }},
+		{"extra shares", []byte("extra shares are ignored"), 3, 6, func(s [][]byte) [][]byte { return s }},
+		{"500 of 1000", bytes.Repeat([]byte{0xc3, 0x5a}, 16), 500, 1000, func(s [][]byte) [][]byte { return s[500:] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := SplitGF16(tt.secret, tt.t, tt.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(shares) != tt.n {
+				t.Fatalf("got %d shares, want %d", len(shares), tt.n)
+			}
+			got, err := CombineGF16(tt.pick(shares))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.secret) {
+				t.Fatalf("CombineGF16 = %x, want %x", got, tt.secret)
+			}
+		})
+	}
+}
+
+// forgeGF16 rewrites the threshold of a 16-bit share and fixes its CRC.
+func forgeGF16(share []byte, threshold uint16) []byte {
+	f := bytes.Clone(share)
+	binary.BigEndian.PutUint16(f[5:], threshold)
+	end := len(f) - 4
+	binary.BigEndian.PutUint32(f[end:], crc32.ChecksumIEEE(f[:end]))
+	return f
+}
+
+func TestCombineGF16Rejects(t *testing.T) {
+	shares, err := SplitGF16([]byte("secret"), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v1, err := Split([]byte("secret"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt := bytes.Clone(shares[1])
+	corrupt[headLenGF16] ^= 1
+	tests := []struct {
+		name    string
+		shares  [][]byte
+		wantErr error
+	}{
+		{"threshold 1", [][]byte{forgeGF16(shares[0], 1), shares[1]}, ErrInvalidParams},
+		{"threshold 0", [][]byte{forgeGF16(shares[0], 0), shares[1], shares[2]}, ErrInvalidParams},
+		{"too few", shares[:2], ErrInsufficientShares},
+		{"GF(256) share", v1, ErrVersionMismatch},
+		{"corrupt", [][]byte{shares[0], corrupt, shares[2]}, ErrCRCMismatch},
+		{"duplicate", [][]byte{shares[0], shares[0], shares[2]}, ErrDuplicateIndex},
+		{"threshold mismatch", [][]byte{shares[0], forgeGF16(shares[1], 4), shares[2]}, ErrHeaderMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CombineGF16(tt.shares); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CombineGF16 error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// header.go
+package shamir
+
+import "fmt"
+
+// ShareIndex returns the x-coordinate (1..n) of a raw share.
+func ShareIndex(share []byte) (byte, error) {
+	if len(share) < headLen {
+		return 0, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+	}
+	return share[9], nil
+}
+
+// ShareThreshold returns the threshold recorded in a raw share's header.
+func ShareThreshold(share []byte) (byte, error) {
+	if len(share) < headLen {
+		return 0, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+	}
+	return share[5], nil
+}
+
+// ShareTotal returns the total share count recorded in a raw share's header.
+func ShareTotal(share []byte) (byte, error) {
+	if len(share) < headLen {
+		return 0, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+	}
+	return share[6], nil
+}
+
+// SharePayload returns the payload of a raw share (without header or CRC).
+// The returned slice aliases share.
+func SharePayload(share []byte) ([]byte, error) {
+	info, err := parseFrame(share)
+	if err != nil {
+		return nil, err
+	}
+	return info.payload, nil
+}
+
+// ShareHeader holds the header fields of a share that EditShareHeader may
+// change. The version, flags and length define the framing and can't be
+// edited.
+type ShareHeader struct {
+	Threshold byte
+	Total     byte
+	Index     byte
+}
+
+// RecomputeCRC rewrites the trailing CRC32 (or SHA-256 tag) of share in
+// place over its current contents, after an authorized edit of its bytes.
+// It checks the framing but not the old tag, which is expected to be stale.
+// Shares bound to AAD fail with ErrAADRequired, since their HMAC tag needs
+// the associated data.
+func RecomputeCRC(share []byte) error {
+	info, err := parseFrame(share)
+	if err != nil {
+		return err
+	}
+	if info.integrity() == integrityHMAC {
+		return ErrAADRequired
+	}
+	sealShare(share, nil)
+	return nil
+}
+
+// EditShareHeader verifies share, applies edit to a copy of its header and
+// returns the edited share with a fresh CRC32 (or SHA-256 tag). The input is
+// left untouched, so a failed edit changes nothing. The edited header must
+// still be valid: threshold 2..total, total up to 255, index 1..total.
+func EditShareHeader(share []byte, edit func(*ShareHeader)) ([]byte, error) {
+	info, err := parseShare(share)
+	if err != nil {
+		return nil, err
+	}
+	h := ShareHeader{Threshold: info.threshold, Total: info.total, Index: info.index}
+	edit(&h)
+	if err := validateParams(int(h.Threshold), int(h.Total)); err != nil {
+		return nil, err
+	}
+	if h.Index == 0 || h.Index > h.Total {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidIndex, h.Index)
+	}
+	out := append([]byte(nil), share...)
+	out[5], out[6], out[9] = h.Threshold, h.Total, h.Index
+	sealShare(out, nil)
+	return out, nil
+}
@@ -0,0 +1,106 @@
+// header_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRecomputeCRCAfterTotalEdit(t *testing.T) {
+	secret := []byte("fix the total byte")
+	tests := []struct {
+		name string
+		opts *SplitOptions // nil means Split
+	}{
+		{"v1", nil},
+		{"sha256", &SplitOptions{SHA256Tag: true}},
+		{"digest and meta", &SplitOptions{EmbedDigest: true, SchemeID: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var shares [][]byte
+			var err error
+			if tt.opts == nil {
+				shares, err = Split(secret, 2, 3)
+			} else {
+				shares, err = SplitWithOptions(secret, 2, 3, *tt.opts)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range shares {
+				s[6] = 5 // the shares were meant to be 2-of-5
+				if err := ValidateShare(s); !errors.Is(err, ErrCRCMismatch) {
+					t.Fatalf("edited share validates: %v", err)
+				}
+				if err := RecomputeCRC(s); err != nil {
+					t.Fatal(err)
+				}
+				if err := ValidateShare(s); err != nil {
+					t.Fatalf("re-CRCed share: %v", err)
+				}
+			}
+			got, err := Combine(shares[1:])
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Fatalf("Combine = %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestRecomputeCRCRejects(t *testing.T) {
+	bound, err := SplitWithOptions([]byte("bound"), 2, 3, SplitOptions{AAD: []byte("ctx")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RecomputeCRC(bound[0]); !errors.Is(err, ErrAADRequired) {
+		t.Fatalf("RecomputeCRC on an AAD share: %v", err)
+	}
+	if err := RecomputeCRC([]byte("SHAM")); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("RecomputeCRC on a short share: %v", err)
+	}
+}
+
+func TestEditShareHeader(t *testing.T) {
+	secret := []byte("edit header")
+	shares, err := Split(secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		edit    func(*ShareHeader)
+		wantErr error
+	}{
+		{"raise total", func(h *ShareHeader) { h.Total = 5 }, nil},
+		{"no change", func(*ShareHeader) {}, nil},
+		{"index 0", func(h *ShareHeader) { h.Index = 0 }, ErrInvalidIndex},
+		{"index above total", func(h *ShareHeader) { h.Index = 4 }, ErrInvalidIndex},
+		{"threshold above total", func(h *ShareHeader) { h.Threshold = 4 }, ErrInvalidParams},
+		{"threshold 1", func(h *ShareHeader) { h.Threshold = 1 }, ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := bytes.Clone(shares[0])
+			out, err := EditShareHeader(shares[0], tt.edit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EditShareHeader error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(shares[0], orig) {
+				t.Fatal("input share modified")
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			other, err := EditShareHeader(shares[1], tt.edit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Combine([][]byte{out, other})
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Fatalf("Combine = %q, %v", got, err)
+			}
+		})
+	}
+}
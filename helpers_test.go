@@ -0,0 +1,151 @@
+// helpers_test.go
+package shamir
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errNotFound = errors.New("not found")
+
+// mapStorage is a minimal in-memory IStorage for tests in this package,
+// which can't import the storage drivers.
+type mapStorage struct {
+	mu     sync.Mutex
+	shares map[byte][]byte
+}
+
+func newMapStorage() *mapStorage { return &mapStorage{shares: make(map[byte][]byte)} }
+
+func (m *mapStorage) SetShare(index byte, share []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shares[index] = append([]byte(nil), share...)
+	return nil
+}
+
+func (m *mapStorage) GetShare(index byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.shares[index]
+	if !ok {
+		return nil, errNotFound
+	}
+	return append([]byte(nil), s...), nil
+}
+
+func (m *mapStorage) ListShares() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]byte, 0, len(m.shares))
+	for idx := range m.shares {
+		out = append(out, idx)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+func (m *mapStorage) DeleteShare(index byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.shares[index]; !ok {
+		return errNotFound
+	}
+	delete(m.shares, index)
+	return nil
+}
+
+func (m *mapStorage) BatchSet(shares map[byte][]byte) error {
+	for idx, s := range shares {
+		if err := m.SetShare(idx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeClock is a Clock whose time only moves when Advance is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+func newFakeClock(now time.Time) *fakeClock { return &fakeClock{now: now} }
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance moves the clock forward, firing every ticker that comes due. Like
+// a time.Ticker, a ticker whose previous tick hasn't been received drops
+// the new one.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		for !t.stopped && !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+// Active returns the periods of the tickers that haven't been stopped.
+func (c *fakeClock) Active() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []time.Duration
+	for _, t := range c.tickers {
+		if !t.stopped {
+			out = append(out, t.period)
+		}
+	}
+	return out
+}
+
+type fakeTicker struct {
+	clock   *fakeClock
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+// waitFor polls cond until it holds, failing the test after a few seconds.
+// It waits on goroutines, not on time: the fake clock drives the schedule.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,113 @@
+// interpolator.go
+package shamir
+
+import (
+	"fmt"
+)
+
+// Interpolator reconstructs a secret from shares added one at a time, for
+// shares trickling in from custodians. It keeps the Newton form of the
+// interpolating polynomial, so each Add costs O(secretLen * shares so far)
+// and nothing is recomputed from scratch. Shares beyond the threshold are
+// checked against the polynomial the earlier ones define. It is not safe for
+// concurrent use.
+type Interpolator struct {
+	first  []byte    // copy of the first share, for unpacking the secret
+	info   shareInfo // parsed view of first
+	xs     []byte
+	coeffs [][]byte // Newton coefficients c_k, one byte per secret position
+	prod   byte     // product of xs: the Newton basis at x=0 for the next node
+	acc    []byte   // polynomial at x=0 so far
+}
+
+// NewInterpolator returns an empty Interpolator. Its threshold is fixed by
+// the first share added.
+func NewInterpolator() *Interpolator {
+	return &Interpolator{prod: 1}
+}
+
+// Add verifies a share and folds it into the reconstruction. A share from a
+// different split fails with ErrHeaderMismatch (or ErrSchemeMismatch), a
+// repeated index with ErrDuplicateIndex, and a share past the threshold
+// that doesn't lie on the polynomial of the earlier ones with
+// ErrInconsistentShares. A header claiming a threshold below 2 fails with
+// ErrInvalidParams, as in Combine. The interpolator is unchanged when Add
+// fails.
+func (ip *Interpolator) Add(share []byte) error {
+	info, err := parseShare(share)
+	if err != nil {
+		return err
+	}
+	if info.threshold < 2 {
+		// a forged header would otherwise "reconstruct" from one share
+		return fmt.Errorf("%w: threshold %d", ErrInvalidParams, info.threshold)
+	}
+	if len(ip.xs) > 0 {
+		if !sameSplit(info, ip.info) {
+			return splitMismatch(ip.info, info)
+		}
+		if info.threshold != ip.info.threshold {
+			return fmt.Errorf("%w: share %d has threshold %d", ErrHeaderMismatch, info.index, info.threshold)
+		}
+		for _, x := range ip.xs {
+			if x == info.index {
+				return fmt.Errorf("%w: %d", ErrDuplicateIndex, x)
+			}
+		}
+	}
+
+	x := info.index
+	k := len(ip.xs)
+	// d = prod (x - x_i) over the existing nodes
+	d := byte(1)
+	for _, xi := range ip.xs {
+		d = mul(d, x^xi)
+	}
+	dInv, _ := inv(d) // d != 0: indices are distinct and non-zero
+	c := make([]byte, len(info.payload))
+	nonzero := false
+	for j, y := range info.payload {
+		// evaluate the current Newton form at x by Horner's rule
+		var v byte
+		for i := k - 1; i >= 0; i-- {
+			v = mul(v, x^ip.xs[i]) ^ ip.coeffs[i][j]
+		}
+		c[j] = mul(y^v, dInv)
+		if c[j] != 0 {
+			nonzero = true
+		}
+	}
+	if k >= int(info.threshold) && nonzero {
+		return fmt.Errorf("%w: share %d disagrees with the first %d", ErrInconsistentShares, x, k)
+	}
+
+	if k == 0 {
+		ip.first = append([]byte(nil), share...)
+		ip.info, _ = parseFrame(ip.first)
+		ip.acc = make([]byte, len(info.payload))
+	}
+	for j := range ip.acc {
+		ip.acc[j] ^= mul(c[j], ip.prod)
+	}
+	ip.prod = mul(ip.prod, x)
+	ip.xs = append(ip.xs, x)
+	ip.coeffs = append(ip.coeffs, c)
+	return nil
+}
+
+// Len returns the number of shares added.
+func (ip *Interpolator) Len() int { return len(ip.xs) }
+
+// Ready reports whether threshold shares have been added.
+func (ip *Interpolator) Ready() bool {
+	return len(ip.xs) > 0 && len(ip.xs) >= int(ip.info.threshold)
+}
+
+// Secret returns the reconstructed secret once threshold shares have been
+// added, and ErrInsufficientShares before that.
+func (ip *Interpolator) Secret() ([]byte, error) {
+	if !ip.Ready() {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(ip.xs), ip.info.threshold)
+	}
+	return unpackSecret(ip.first, append([]byte(nil), ip.acc...))
+}
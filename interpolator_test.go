@@ -0,0 +1,64 @@
+// interpolator_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestInterpolator(t *testing.T) {
+	secret := []byte("incremental")
+	shares, err := Split(secret, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Split(secret, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := append([]byte(nil), shares[0]...)
+	forged[5] = 1 // threshold 1
+	sealShare(forged, nil)
+
+	tests := []struct {
+		name    string
+		add     [][]byte
+		wantErr error // from the last Add
+		ready   bool
+	}{
+		{"threshold", shares[:3], nil, true},
+		{"extra consistent", shares, nil, true},
+		{"below threshold", shares[:2], nil, false},
+		{"duplicate", [][]byte{shares[0], shares[0]}, ErrDuplicateIndex, false},
+		{"inconsistent extra", [][]byte{shares[0], shares[1], shares[2], other[3]}, ErrInconsistentShares, true},
+		{"forged threshold 1", [][]byte{forged}, ErrInvalidParams, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := NewInterpolator()
+			var err error
+			for _, s := range tt.add {
+				if err = ip.Add(s); err != nil {
+					break
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add = %v, want %v", err, tt.wantErr)
+			}
+			if ip.Ready() != tt.ready {
+				t.Fatalf("Ready = %v, want %v", ip.Ready(), tt.ready)
+			}
+			got, err := ip.Secret()
+			if !tt.ready {
+				if err == nil {
+					t.Fatal("Secret succeeded without enough shares")
+				}
+				return
+			}
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Fatalf("Secret = %q, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// key.go
+package shamir
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// KeyMaterial is a cryptographic key to split: the secret bytes plus public
+// metadata describing them.
+type KeyMaterial struct {
+	Secret    []byte            // the key bytes, e.g. a PEM block or JWK document
+	Algorithm string            // e.g. "RS256", "Ed25519"
+	KeyID     string            // e.g. a JWK "kid"
+	Metadata  map[string]string // any other public attributes
+}
+
+// KeyInfo is the public part of a KeyMaterial, carried in every share of it.
+type KeyInfo struct {
+	Algorithm string            `json:"alg,omitempty"`
+	KeyID     string            `json:"kid,omitempty"`
+	Metadata  map[string]string `json:"meta,omitempty"`
+}
+
+// keyShareJSON is the envelope produced by SplitKey: the ShareJSON fields
+// plus the key's public metadata. FromJSON accepts it and ignores the key.
+type keyShareJSON struct {
+	ShareJSON
+	Key KeyInfo `json:"key"`
+}
+
+// SplitKey splits key.Secret into n shares requiring t to reconstruct, each
+// a JSON envelope like ToJSON's with the key's metadata attached. The
+// metadata is not secret-shared: any single share reveals it, and it is not
+// covered by the share's integrity check. Reconstruct with CombineKey.
+func SplitKey(key KeyMaterial, t, n int) ([][]byte, error) {
+	shares, err := Split(key.Secret, t, n)
+	if err != nil {
+		return nil, err
+	}
+	info := KeyInfo{Algorithm: key.Algorithm, KeyID: key.KeyID, Metadata: key.Metadata}
+	out := make([][]byte, len(shares))
+	for i, s := range shares {
+		j, err := ShareToStruct(s)
+		wipe(s)
+		if err != nil {
+			return nil, err
+		}
+		if out[i], err = json.Marshal(keyShareJSON{ShareJSON: j, Key: info}); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// CombineKey reconstructs a key split with SplitKey, returning the secret
+// bytes with the metadata reattached. Every share must carry the same
+// metadata, or it fails with ErrHeaderMismatch.
+func CombineKey(shares [][]byte) (KeyMaterial, error) {
+	if len(shares) == 0 {
+		return KeyMaterial{}, fmt.Errorf("%w: no shares", ErrInsufficientShares)
+	}
+	raw := make([][]byte, len(shares))
+	defer func() {
+		for _, r := range raw {
+			wipe(r)
+		}
+	}()
+	var info KeyInfo
+	for i, s := range shares {
+		var j keyShareJSON
+		if err := json.Unmarshal(s, &j); err != nil {
+			return KeyMaterial{}, fmt.Errorf("shamir: key share %d: %w", i, err)
+		}
+		if i == 0 {
+			info = j.Key
+		} else if j.Key.Algorithm != info.Algorithm || j.Key.KeyID != info.KeyID || !maps.Equal(j.Key.Metadata, info.Metadata) {
+			return KeyMaterial{}, fmt.Errorf("%w: key share %d has different key metadata", ErrHeaderMismatch, i)
+		}
+		r, err := ShareFromStruct(j.ShareJSON)
+		if err != nil {
+			return KeyMaterial{}, fmt.Errorf("shamir: key share %d: %w", i, err)
+		}
+		raw[i] = r
+	}
+	secret, err := Combine(raw)
+	if err != nil {
+		return KeyMaterial{}, err
+	}
+	return KeyMaterial{Secret: secret, Algorithm: info.Algorithm, KeyID: info.KeyID, Metadata: info.Metadata}, nil
+}
@@ -0,0 +1,82 @@
+// manifest.go
+package shamir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrManifestMismatch is returned when a share does not match the issued manifest.
+var ErrManifestMismatch = errors.New("shamir: share does not match manifest")
+
+// Manifest is a tamper-evident record of an issued share set. It holds only
+// hashes of the shares, never the secret, so it can be published.
+type Manifest struct {
+	Version     byte            `json:"version"`
+	Threshold   byte            `json:"threshold"`
+	TotalShares byte            `json:"total_shares"`
+	SecretLen   int             `json:"secret_len"`
+	Hashes      map[byte]string `json:"hashes"` // index -> hex SHA-256 of the full share
+}
+
+// Fingerprint returns the hex SHA-256 of a raw share.
+func Fingerprint(share []byte) string {
+	sum := sha256.Sum256(share)
+	return hex.EncodeToString(sum[:])
+}
+
+// BuildManifest records the common header and per-index hash of each share.
+func BuildManifest(shares [][]byte) (Manifest, error) {
+	var m Manifest
+	if len(shares) == 0 {
+		return m, fmt.Errorf("%w: no shares", ErrInsufficientShares)
+	}
+	h, err := parseFrame(shares[0])
+	if err != nil {
+		return m, err
+	}
+	m.Version = h.version
+	m.Threshold = h.threshold
+	m.TotalShares = h.total
+	m.SecretLen = len(h.payload)
+	m.Hashes = make(map[byte]string, len(shares))
+	for i, s := range shares {
+		info, err := parseFrame(s)
+		if err != nil {
+			return m, fmt.Errorf("share %d: %w", i, err)
+		}
+		if info.version != m.Version || info.threshold != m.Threshold || info.total != m.TotalShares ||
+			len(info.payload) != m.SecretLen {
+			return m, fmt.Errorf("%w: share %d", ErrHeaderMismatch, info.index)
+		}
+		if _, dup := m.Hashes[info.index]; dup {
+			return m, fmt.Errorf("%w: %d", ErrDuplicateIndex, info.index)
+		}
+		m.Hashes[info.index] = Fingerprint(s)
+	}
+	return m, nil
+}
+
+// VerifyAgainstManifest checks each share against the manifest and returns
+// the indices (ascending) of shares that are unknown or whose hash differs.
+// The error wraps ErrManifestMismatch when any share fails.
+func VerifyAgainstManifest(shares [][]byte, m Manifest) ([]byte, error) {
+	var bad []byte
+	for _, s := range shares {
+		if len(s) < headLen {
+			return nil, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+		}
+		want, ok := m.Hashes[s[9]]
+		if !ok || want != Fingerprint(s) {
+			bad = append(bad, s[9])
+		}
+	}
+	if len(bad) > 0 {
+		sort.Slice(bad, func(i, j int) bool { return bad[i] < bad[j] })
+		return bad, fmt.Errorf("%w: indices %v", ErrManifestMismatch, bad)
+	}
+	return nil, nil
+}
@@ -0,0 +1,134 @@
+// meta.go
+package shamir
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNoMetadata is returned for shares without an embedded creation time and scheme ID.
+	ErrNoMetadata = errors.New("shamir: share carries no metadata")
+	// ErrSchemeMismatch is returned when shares from different schemes are combined.
+	ErrSchemeMismatch = errors.New("shamir: shares belong to different schemes")
+)
+
+// ShareMetadata describes a share without exposing its payload.
+type ShareMetadata struct {
+	Index     byte
+	Threshold byte
+	Total     byte
+	SecretLen int // payload length; the compressed size for compressed shares
+	Version   byte
+	Recovery  bool // part of a recovery set; see SplitWithRecovery
+
+	// HasMeta reports whether CreatedAt and SchemeID were embedded at split
+	// time (see SplitOptions.SchemeID).
+	HasMeta   bool
+	CreatedAt time.Time
+	SchemeID  uint32
+
+	Err error // set when the share failed to parse or verify
+}
+
+// InspectShares returns the header metadata of each share, in input order.
+// Shares are fully verified where possible; a share bound to associated data
+// is reported with its header fields and an ErrAADRequired error.
+func InspectShares(shares [][]byte) []ShareMetadata {
+	out := make([]ShareMetadata, len(shares))
+	for i, s := range shares {
+		info, err := parseFrame(s)
+		if err == nil {
+			_, err = parseShare(s)
+		}
+		out[i].Err = err
+		if info.version == 0 {
+			// the header itself didn't parse
+			continue
+		}
+		out[i].Index = info.index
+		out[i].Threshold = info.threshold
+		out[i].Total = info.total
+		out[i].SecretLen = len(info.payload)
+		out[i].Version = info.version
+		out[i].Recovery = info.flags&flagRecovery != 0
+		if m := info.meta(); m != nil {
+			out[i].HasMeta = true
+			out[i].CreatedAt, out[i].SchemeID = decodeMeta(m)
+		}
+	}
+	return out
+}
+
+// ShareSchemeID returns the scheme ID embedded in a share, or ErrNoMetadata.
+func ShareSchemeID(share []byte) (uint32, error) {
+	m, err := shareMeta(share)
+	if err != nil {
+		return 0, err
+	}
+	_, id := decodeMeta(m)
+	return id, nil
+}
+
+// ShareCreatedAt returns the creation time embedded in a share, or ErrNoMetadata.
+func ShareCreatedAt(share []byte) (time.Time, error) {
+	m, err := shareMeta(share)
+	if err != nil {
+		return time.Time{}, err
+	}
+	created, _ := decodeMeta(m)
+	return created, nil
+}
+
+// CombineScheme is Combine restricted to one logical secret: every share
+// must carry schemeID, so shares from another scheme or generation fail with
+// ErrSchemeMismatch instead of being interpolated.
+func CombineScheme(shares [][]byte, schemeID uint32) ([]byte, error) {
+	for i, s := range shares {
+		id, err := ShareSchemeID(s)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if id != schemeID {
+			return nil, fmt.Errorf("%w: share %d has scheme %d, expected %d", ErrSchemeMismatch, s[9], id, schemeID)
+		}
+	}
+	return Combine(shares)
+}
+
+func shareMeta(share []byte) ([]byte, error) {
+	info, err := parseFrame(share)
+	if err != nil {
+		return nil, err
+	}
+	m := info.meta()
+	if m == nil {
+		return nil, ErrNoMetadata
+	}
+	return m, nil
+}
+
+func encodeMeta(created time.Time, schemeID uint32) []byte {
+	m := make([]byte, metaLen)
+	binary.BigEndian.PutUint64(m, uint64(created.Unix()))
+	binary.BigEndian.PutUint32(m[8:], schemeID)
+	return m
+}
+
+func decodeMeta(m []byte) (time.Time, uint32) {
+	return time.Unix(int64(binary.BigEndian.Uint64(m)), 0).UTC(), binary.BigEndian.Uint32(m[8:])
+}
+
+// splitMismatch explains why share b can't be combined with share a.
+func splitMismatch(a, b shareInfo) error {
+	if ma, mb := a.meta(), b.meta(); ma != nil && mb != nil {
+		_, ia := decodeMeta(ma)
+		_, ib := decodeMeta(mb)
+		if ia != ib {
+			return fmt.Errorf("%w: share %d has scheme %d, expected %d", ErrSchemeMismatch, b.index, ib, ia)
+		}
+	}
+	return fmt.Errorf("%w: share %d", ErrHeaderMismatch, b.index)
+}
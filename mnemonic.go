@@ -0,0 +1,121 @@
+// mnemonic.go
+package shamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidMnemonic is returned when a word sequence is not a valid share mnemonic.
+var ErrInvalidMnemonic = errors.New("shamir: invalid mnemonic")
+
+// wordlistEnglish is the BIP-39 English wordlist: 2048 words, so each word
+// carries 11 bits, and no two words share their first four letters.
+//
+//go:embed wordlist_english.txt
+var wordlistEnglish string
+
+var (
+	mnemonicWords []string
+	mnemonicIndex map[string]int
+)
+
+func init() {
+	mnemonicWords = strings.Fields(wordlistEnglish)
+	if len(mnemonicWords) != 2048 {
+		panic("shamir: embedded wordlist must have 2048 words")
+	}
+	mnemonicIndex = make(map[string]int, len(mnemonicWords))
+	for i, w := range mnemonicWords {
+		mnemonicIndex[w] = i
+	}
+}
+
+// mnemonicChecksumLen is the number of SHA-256 bytes appended before encoding.
+const mnemonicChecksumLen = 4
+
+// EncodeMnemonic renders a share as words from the BIP-39 English wordlist
+// for transcription on paper. The share is followed by a 4-byte SHA-256
+// checksum, so DecodeMnemonic detects wrong, missing or swapped words.
+// The words are not a BIP-39 seed phrase and aren't accepted by wallets.
+func EncodeMnemonic(share []byte) ([]string, error) {
+	if _, err := parseFrame(share); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(share)
+	data := make([]byte, 0, len(share)+mnemonicChecksumLen)
+	data = append(append(data, share...), sum[:mnemonicChecksumLen]...)
+	defer wipe(data)
+
+	words := make([]string, 0, (len(data)*8+10)/11)
+	var acc uint32
+	bits := 0
+	for _, b := range data {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 11 {
+			bits -= 11
+			words = append(words, mnemonicWords[(acc>>bits)&0x7ff])
+		}
+	}
+	if bits > 0 {
+		// pad the final word with zero bits
+		words = append(words, mnemonicWords[(acc<<(11-bits))&0x7ff])
+	}
+	return words, nil
+}
+
+// DecodeMnemonic reverses EncodeMnemonic. Words are matched case-insensitively
+// and surrounding or embedded whitespace is ignored, so a whole transcribed
+// line may be passed as a single element. The decoded share is checked as
+// by ValidateShare, so a share bound to AAD decodes and its tag is verified
+// when it is combined with CombineWithAAD.
+func DecodeMnemonic(words []string) ([]byte, error) {
+	fields := strings.Fields(strings.ToLower(strings.Join(words, " ")))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no words", ErrInvalidMnemonic)
+	}
+	data := make([]byte, 0, len(fields)*11/8)
+	var acc uint32
+	bits := 0
+	for i, w := range fields {
+		v, ok := mnemonicIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: word %d (%q) is not in the wordlist", ErrInvalidMnemonic, i+1, w)
+		}
+		acc = acc<<11 | uint32(v)
+		bits += 11
+		for bits >= 8 {
+			bits -= 8
+			data = append(data, byte(acc>>bits))
+		}
+	}
+	defer wipe(data)
+	if acc&(1<<bits-1) != 0 {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	// The share header declares its own length; anything after the checksum
+	// can only be the padding of the last word.
+	_, want, err := parseHeader(data)
+	if err != nil || want+mnemonicChecksumLen > len(data) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	end := want + mnemonicChecksumLen
+	if len(data)-end > 1 || (len(data) > end && data[end] != 0) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	sum := sha256.Sum256(data[:want])
+	if !bytes.Equal(sum[:mnemonicChecksumLen], data[want:end]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	share := make([]byte, want)
+	copy(share, data[:want])
+	if err := ValidateShare(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
@@ -0,0 +1,67 @@
+// mnemonic_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestMnemonicRoundTrip(t *testing.T) {
+	secret := []byte("mnemonic secret")
+	tests := []struct {
+		name string
+		opts SplitOptions
+		aad  []byte
+	}{
+		{"v1", SplitOptions{}, nil},
+		{"sha256", SplitOptions{SHA256Tag: true}, nil},
+		{"meta", SplitOptions{SchemeID: 7}, nil},
+		{"aad", SplitOptions{AAD: []byte("tenant-a")}, []byte("tenant-a")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := SplitWithOptions(secret, 2, 3, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := make([][]byte, len(shares))
+			for i, s := range shares {
+				words, err := EncodeMnemonic(s)
+				if err != nil {
+					t.Fatalf("EncodeMnemonic: %v", err)
+				}
+				if decoded[i], err = DecodeMnemonic(words); err != nil {
+					t.Fatalf("DecodeMnemonic: %v", err)
+				}
+				if !bytes.Equal(decoded[i], s) {
+					t.Fatal("round trip changed the share")
+				}
+			}
+			var got []byte
+			if tt.aad != nil {
+				got, err = CombineWithAAD(decoded, tt.aad)
+			} else {
+				got, err = Combine(decoded)
+			}
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Fatalf("combine = %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestDecodeMnemonicRejectsTypo(t *testing.T) {
+	shares, err := Split([]byte("typo"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := EncodeMnemonic(shares[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	words[3], words[4] = words[4], words[3]
+	if _, err := DecodeMnemonic(words); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("DecodeMnemonic with swapped words: %v, want ErrInvalidMnemonic", err)
+	}
+}
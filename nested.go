@@ -0,0 +1,70 @@
+// nested.go
+package shamir
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SchemeParams is a plain (threshold, total) pair.
+type SchemeParams struct {
+	Threshold int
+	Total     int
+}
+
+// SplitNested performs two-tier sharing: the secret is split with the outer
+// parameters, then each outer share is itself split with the inner
+// parameters. The result holds, per outer share, that team's inner share set.
+func SplitNested(secret []byte, outer, inner SchemeParams) ([][][]byte, error) {
+	if err := validateParams(inner.Threshold, inner.Total); err != nil {
+		return nil, fmt.Errorf("inner: %w", err)
+	}
+	outerShares, err := Split(secret, outer.Threshold, outer.Total)
+	if err != nil {
+		return nil, fmt.Errorf("outer: %w", err)
+	}
+	teams := make([][][]byte, len(outerShares))
+	for i, s := range outerShares {
+		teams[i], err = Split(s, inner.Threshold, inner.Total)
+		if err != nil {
+			return nil, fmt.Errorf("inner split %d: %w", i+1, err)
+		}
+		wipe(s)
+	}
+	return teams, nil
+}
+
+// CombineNested reconstructs each team's outer share from its inner shares,
+// then combines the outer shares. Teams that are absent (empty) or that fail
+// to reach their inner quorum are skipped; recovery fails if too few outer
+// shares remain.
+func CombineNested(teams [][][]byte) ([]byte, error) {
+	var outer [][]byte
+	var errs []error
+	for i, team := range teams {
+		if len(team) == 0 {
+			continue
+		}
+		s, err := Combine(team)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("team %d: %w", i, err))
+			continue
+		}
+		outer = append(outer, s)
+	}
+	secret, err := Combine(outer)
+	for _, s := range outer {
+		wipe(s)
+	}
+	if err != nil {
+		return nil, errors.Join(append([]error{err}, errs...)...)
+	}
+	return secret, nil
+}
+
+// wipe zeroes b.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
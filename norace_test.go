@@ -0,0 +1,7 @@
+// norace_test.go
+
+//go:build !race
+
+package shamir
+
+const raceEnabled = false
@@ -0,0 +1,56 @@
+// padded.go
+package shamir
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Padded shares hide the secret's length: the value actually split is
+//
+//	secretLen(2) || secret || zeros
+//
+// padded to a fixed size, so every share of every secret split with the
+// same paddedLen has the same size. The true length is secret-shared along
+// with the secret, so it is only learned on reconstruction.
+
+// SplitPadded is Split with the secret padded to paddedLen bytes before
+// splitting. paddedLen must be at least len(secret). Reconstruct with
+// CombinePadded; Combine returns the padded form.
+func SplitPadded(secret []byte, t, n, paddedLen int) ([][]byte, error) {
+	if paddedLen < len(secret) {
+		return nil, fmt.Errorf("%w: padded length %d is shorter than the %d-byte secret", ErrInvalidParams, paddedLen, len(secret))
+	}
+	if paddedLen+2 > 0xFFFF {
+		return nil, fmt.Errorf("%w: padded length %d exceeds %d", ErrInvalidParams, paddedLen, 0xFFFF-2)
+	}
+	buf := make([]byte, 2+paddedLen)
+	defer wipe(buf)
+	binary.BigEndian.PutUint16(buf, uint16(len(secret)))
+	copy(buf[2:], secret)
+	return Split(buf, t, n)
+}
+
+// CombinePadded reconstructs a secret split with SplitPadded and strips the
+// padding. It fails with ErrInconsistentShares if the reconstruction isn't
+// validly padded, which is also what shares from plain Split usually yield.
+func CombinePadded(shares [][]byte) ([]byte, error) {
+	buf, err := Combine(shares)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(buf)
+	if len(buf) < 2 {
+		return nil, fmt.Errorf("%w: padded secret too short", ErrInconsistentShares)
+	}
+	n := int(binary.BigEndian.Uint16(buf))
+	if n > len(buf)-2 {
+		return nil, fmt.Errorf("%w: padded secret length %d exceeds %d", ErrInconsistentShares, n, len(buf)-2)
+	}
+	for _, b := range buf[2+n:] {
+		if b != 0 {
+			return nil, fmt.Errorf("%w: bad padding", ErrInconsistentShares)
+		}
+	}
+	return append([]byte(nil), buf[2:2+n]...), nil
+}
@@ -0,0 +1,86 @@
+// policy.go
+package shamir
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrPolicyMismatch is returned when a collected share set does not match
+// the issued policy.
+var ErrPolicyMismatch = errors.New("shamir: share set does not match policy")
+
+// Policy is the dealer's record of a k-of-n distribution: the scheme, which
+// custodian received which index, and the fingerprint of each issued share.
+// Like a Manifest it never contains the secret, so it can be handed to
+// auditors or signed and published.
+type Policy struct {
+	Threshold   byte          `json:"threshold"`
+	TotalShares byte          `json:"total_shares"`
+	SecretLen   int           `json:"secret_len"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Custodians  []PolicyEntry `json:"custodians"` // ascending by index
+}
+
+// PolicyEntry binds a custodian to a share index and its fingerprint.
+type PolicyEntry struct {
+	Index       byte   `json:"index"`
+	Custodian   string `json:"custodian"`
+	Fingerprint string `json:"fingerprint"` // see Fingerprint
+}
+
+// GeneratePolicy builds the policy for a freshly split share set.
+// custodians must name a custodian for exactly the indices in shares.
+func GeneratePolicy(shares [][]byte, custodians map[byte]string) (Policy, error) {
+	var p Policy
+	m, err := BuildManifest(shares)
+	if err != nil {
+		return p, err
+	}
+	if len(custodians) != len(m.Hashes) {
+		return p, fmt.Errorf("%w: %d custodians for %d shares", ErrInvalidParams, len(custodians), len(m.Hashes))
+	}
+	p.Threshold = m.Threshold
+	p.TotalShares = m.TotalShares
+	p.SecretLen = m.SecretLen
+	p.CreatedAt = time.Now().UTC()
+	p.Custodians = make([]PolicyEntry, 0, len(m.Hashes))
+	for idx, fp := range m.Hashes {
+		name, ok := custodians[idx]
+		if !ok {
+			return Policy{}, fmt.Errorf("%w: no custodian for share %d", ErrInvalidParams, idx)
+		}
+		p.Custodians = append(p.Custodians, PolicyEntry{Index: idx, Custodian: name, Fingerprint: fp})
+	}
+	sort.Slice(p.Custodians, func(i, j int) bool { return p.Custodians[i].Index < p.Custodians[j].Index })
+	return p, nil
+}
+
+// VerifyPolicy confirms that every collected share was issued under p: the
+// header must match the scheme and the share's fingerprint must be the one
+// recorded for its index. The error names the custodian whose share failed.
+func VerifyPolicy(shares [][]byte, p Policy) error {
+	byIndex := make(map[byte]PolicyEntry, len(p.Custodians))
+	for _, e := range p.Custodians {
+		byIndex[e.Index] = e
+	}
+	for i, s := range shares {
+		info, err := parseFrame(s)
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		if info.threshold != p.Threshold || info.total != p.TotalShares || len(info.payload) != p.SecretLen {
+			return fmt.Errorf("%w: share %d header differs from policy", ErrPolicyMismatch, info.index)
+		}
+		e, ok := byIndex[info.index]
+		if !ok {
+			return fmt.Errorf("%w: share %d was not issued", ErrPolicyMismatch, info.index)
+		}
+		if Fingerprint(s) != e.Fingerprint {
+			return fmt.Errorf("%w: share %d does not match the share issued to %q", ErrPolicyMismatch, info.index, e.Custodian)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// quorum.go
+package shamir
+
+import "fmt"
+
+// CheckQuorum reports whether the reachable custodians in available can
+// reconstruct a secret with the given threshold and, if not, how many more
+// are needed. Duplicate and zero indices are not counted.
+func CheckQuorum(available []byte, threshold int) (ok bool, missing int) {
+	var seen [256]bool
+	n := 0
+	for _, idx := range available {
+		if idx != 0 && !seen[idx] {
+			seen[idx] = true
+			n++
+		}
+	}
+	if n >= threshold {
+		return true, 0
+	}
+	return false, threshold - n
+}
+
+// CheckQuorumStorage is CheckQuorum over the shares held by st. Every listed
+// share is read and checked with ValidateShare; only valid shares stored
+// under their own index count. Failing to list st is an error; failing to
+// read or validate an individual share just leaves it uncounted.
+func CheckQuorumStorage(st IStorage, threshold int) (ok bool, missing int, err error) {
+	indices, err := st.ListShares()
+	if err != nil {
+		return false, 0, fmt.Errorf("shamir: list shares: %w", err)
+	}
+	valid := make([]byte, 0, len(indices))
+	for _, idx := range indices {
+		s, err := st.GetShare(idx)
+		if err != nil || ValidateShare(s) != nil || s[9] != idx {
+			continue
+		}
+		valid = append(valid, idx)
+	}
+	ok, missing = CheckQuorum(valid, threshold)
+	return ok, missing, nil
+}
@@ -0,0 +1,9 @@
+// race_test.go
+
+//go:build race
+
+package shamir
+
+// raceEnabled reports whether the race detector is on; its instrumentation
+// allocates, so allocation counts are meaningless under it.
+const raceEnabled = true
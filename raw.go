@@ -0,0 +1,86 @@
+// raw.go
+package shamir
+
+import (
+	"fmt"
+)
+
+// Raw shares are the header-less form index(1) || payload, for transports
+// where every byte counts. They carry no magic, parameters or checksum: the
+// threshold, total and secret length must be agreed out of band, and a
+// corrupted raw share silently yields a wrong secret.
+
+// SplitRaw is Split producing raw shares.
+func SplitRaw(secret []byte, t, n int) ([][]byte, error) {
+	shares, err := Split(secret, t, n)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range shares {
+		shares[i] = rawShare(s[9], s[headLen:len(s)-4])
+	}
+	return shares, nil
+}
+
+// StripShare verifies a share and returns its raw form. Compressed shares
+// and shares bound to AAD are rejected, since their raw form could not be
+// combined by CombineWithParams.
+func StripShare(share []byte) ([]byte, error) {
+	info, err := parseShare(share)
+	if err != nil {
+		return nil, err
+	}
+	if info.flags&flagCompressed != 0 {
+		return nil, fmt.Errorf("%w: cannot strip a compressed share", ErrInvalidParams)
+	}
+	return rawShare(info.index, info.payload), nil
+}
+
+func rawShare(index byte, payload []byte) []byte {
+	raw := make([]byte, 1+len(payload))
+	raw[0] = index
+	copy(raw[1:], payload)
+	return raw
+}
+
+// CombineWithParams reconstructs the secret from raw shares, given the
+// parameters their headers would have carried. Every raw share must be
+// 1+secretLen bytes with a distinct index in 1..total; the lowest threshold
+// indices are used.
+func CombineWithParams(rawShares [][]byte, threshold, total, secretLen int) ([]byte, error) {
+	if err := validateParams(threshold, total); err != nil {
+		return nil, err
+	}
+	if secretLen < 0 || secretLen > 0xFFFF {
+		return nil, fmt.Errorf("%w: secret length %d", ErrInvalidParams, secretLen)
+	}
+	if secretLen > MaxSecretLen {
+		return nil, fmt.Errorf("%w: secret length %d", ErrSecretTooLarge, secretLen)
+	}
+	if len(rawShares) < threshold {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(rawShares), threshold)
+	}
+	var byIndex [256][]byte
+	for i, r := range rawShares {
+		if len(r) != 1+secretLen {
+			return nil, fmt.Errorf("%w: raw share %d is %d bytes, want %d", ErrLengthMismatch, i, len(r), 1+secretLen)
+		}
+		idx := r[0]
+		if idx == 0 || int(idx) > total {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidIndex, idx)
+		}
+		if byIndex[idx] != nil {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateIndex, idx)
+		}
+		byIndex[idx] = r[1:]
+	}
+	xs := make([]byte, 0, threshold)
+	data := make([][]byte, 0, threshold)
+	for idx := 1; idx <= total && len(xs) < threshold; idx++ {
+		if byIndex[idx] != nil {
+			xs = append(xs, byte(idx))
+			data = append(data, byIndex[idx])
+		}
+	}
+	return interpolate(lagrange(xs), data), nil
+}
@@ -0,0 +1,64 @@
+// recovery.go
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ErrNotRecoveryShare is returned by BreakGlassRecovery for a share that
+// isn't part of a recovery set.
+var ErrNotRecoveryShare = errors.New("shamir: not a recovery share")
+
+// SplitWithRecovery splits secret twice with independent polynomials: a
+// primary (t, n) set for day-to-day custodians and a recovery (rt, rn) set
+// for a separate break-glass quorum. Either set alone reconstructs the
+// secret, and shares of the two sets can't be mixed. Primary shares are
+// plain v1 shares; recovery shares are v2 shares marked as recovery shares
+// (see IsRecoveryShare), so the index spaces overlap but the sets are always
+// distinguishable.
+func SplitWithRecovery(secret []byte, t, n, rt, rn int) (primary, recovery [][]byte, err error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, nil, err
+	}
+	if err := validateParams(rt, rn); err != nil {
+		return nil, nil, fmt.Errorf("recovery set: %w", err)
+	}
+	primary, err = split(rand.Reader, secret, t, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	recovery, err = splitFramed(rand.Reader, secret, rt, rn, frame{version: versionV2, flags: flagRecovery}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return primary, recovery, nil
+}
+
+// IsRecoveryShare reports whether share belongs to a recovery set produced
+// by SplitWithRecovery. Malformed shares report false.
+func IsRecoveryShare(share []byte) bool {
+	info, err := parseFrame(share)
+	return err == nil && info.flags&flagRecovery != 0
+}
+
+// BreakGlassRecovery retrieves the given indices from st, which must hold a
+// recovery set (see SplitWithRecovery), and combines threshold of them.
+// A primary share fails with ErrNotRecoveryShare, so a day-to-day quorum
+// can't be passed off as the break-glass one.
+func BreakGlassRecovery(st IStorage, indices []byte, threshold int) ([]byte, error) {
+	shs, err := RetrieveShares(indices, st)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range shs {
+		if !IsRecoveryShare(s) {
+			return nil, fmt.Errorf("%w: index %d", ErrNotRecoveryShare, indices[i])
+		}
+	}
+	if len(shs) < threshold {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(shs), threshold)
+	}
+	return Combine(shs[:threshold])
+}
@@ -0,0 +1,167 @@
+// repair.go
+package shamir
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// repairSearchLimit caps the number of threshold subsets RepairShares tries
+// while looking for one free of corrupt shares.
+const repairSearchLimit = 1 << 16
+
+// RepairReport describes what RepairShares found. Indices are listed in the
+// order the shares were supplied.
+type RepairReport struct {
+	Threshold int
+	// Invalid lists shares that failed their integrity check.
+	Invalid []byte
+	// Inconsistent lists shares that passed their integrity check but do not
+	// lie on the polynomial the other shares agree on.
+	Inconsistent []byte
+	// Repaired lists every share that was rebuilt: Invalid and Inconsistent
+	// together.
+	Repaired []byte
+}
+
+// RepairShares locates corrupt shares in a full or partial set and rebuilds
+// them from the rest by interpolation, returning the set with the corrupt
+// shares replaced (other shares are copied unchanged).
+//
+// Two kinds of damage are handled. A share that fails its CRC or SHA-256
+// check is known to be bad, and any number of them can be rebuilt as long as
+// threshold good shares remain, i.e. up to n - threshold of n. A share that
+// passes its check yet disagrees with the others (corrupted and then
+// re-sealed) must be outvoted: with m shares passing their checks, at most
+// (m - threshold) / 2 such shares can be located. With exactly threshold
+// good shares nothing can be cross-checked, so they are trusted.
+//
+// Every share's header must still be readable and describe the same split,
+// since the header gives the index to rebuild; a damaged header is an error.
+// Shares bound to AAD are not supported.
+func RepairShares(shares [][]byte) (repaired [][]byte, report RepairReport, err error) {
+	if len(shares) == 0 {
+		return nil, report, fmt.Errorf("%w: no shares", ErrInsufficientShares)
+	}
+	infos := make([]shareInfo, len(shares))
+	bad := make([]bool, len(shares))
+	var good []int
+	seen := make(map[byte]bool, len(shares))
+	for i, s := range shares {
+		info, err := parseFrame(s)
+		if err != nil {
+			return nil, report, fmt.Errorf("share %d: %w", i, err)
+		}
+		if info.integrity() == integrityHMAC {
+			return nil, report, fmt.Errorf("%w: share %d", ErrAADRequired, info.index)
+		}
+		if i > 0 && (!sameSplit(info, infos[0]) || info.threshold != infos[0].threshold) {
+			return nil, report, splitMismatch(infos[0], info)
+		}
+		if info.index == 0 {
+			return nil, report, fmt.Errorf("%w: index 0", ErrInvalidIndex)
+		}
+		if seen[info.index] {
+			return nil, report, fmt.Errorf("%w: %d", ErrDuplicateIndex, info.index)
+		}
+		seen[info.index] = true
+		infos[i] = info
+		if _, err := parseShare(s); err != nil {
+			bad[i] = true
+			report.Invalid = append(report.Invalid, info.index)
+			continue
+		}
+		good = append(good, i)
+	}
+	t := int(infos[0].threshold)
+	report.Threshold = t
+	if t < 2 {
+		return nil, report, fmt.Errorf("%w: threshold %d", ErrInvalidParams, t)
+	}
+	if len(good) < t {
+		return nil, report, fmt.Errorf("%w: %d shares pass their integrity check, need %d", ErrInsufficientShares, len(good), t)
+	}
+
+	subset, off, ok := findConsistentSubset(infos, good, t)
+	if !ok {
+		return nil, report, fmt.Errorf("%w: more than %d of %d shares disagree", ErrInconsistentShares, (len(good)-t)/2, len(good))
+	}
+	for _, i := range off {
+		bad[i] = true
+		report.Inconsistent = append(report.Inconsistent, infos[i].index)
+	}
+
+	xs := make([]byte, t)
+	data := make([][]byte, t)
+	for k, i := range subset {
+		xs[k] = infos[i].index
+		data[k] = infos[i].payload
+	}
+	repaired = make([][]byte, len(shares))
+	for i, s := range shares {
+		if !bad[i] {
+			repaired[i] = append([]byte(nil), s...)
+			continue
+		}
+		info := infos[i]
+		payload := interpolate(lagrangeAt(xs, info.index), data)
+		repaired[i] = newShare(info.frame, info.threshold, info.total, info.index, payload)
+		report.Repaired = append(report.Repaired, info.index)
+	}
+	return repaired, report, nil
+}
+
+// findConsistentSubset searches the threshold subsets of good (positions
+// into infos) for one whose polynomial the largest possible majority agrees
+// with. It returns the subset and the good positions that disagree with it,
+// or false if every subset tried leaves more than (len(good)-t)/2 dissenters.
+func findConsistentSubset(infos []shareInfo, good []int, t int) (subset, off []int, ok bool) {
+	maxOff := (len(good) - t) / 2
+	pick := make([]int, t) // positions into good, ascending
+	for k := range pick {
+		pick[k] = k
+	}
+	xs := make([]byte, t)
+	data := make([][]byte, t)
+	for tries := 0; tries < repairSearchLimit; tries++ {
+		for k, p := range pick {
+			xs[k] = infos[good[p]].index
+			data[k] = infos[good[p]].payload
+		}
+		off = off[:0]
+		next := 0
+		for p, i := range good {
+			if next < t && pick[next] == p {
+				next++
+				continue
+			}
+			want := interpolate(lagrangeAt(xs, infos[i].index), data)
+			if !bytes.Equal(want, infos[i].payload) {
+				off = append(off, i)
+				if len(off) > maxOff {
+					break
+				}
+			}
+		}
+		if len(off) <= maxOff {
+			subset = make([]int, t)
+			for k, p := range pick {
+				subset[k] = good[p]
+			}
+			return subset, off, true
+		}
+		// advance to the next combination in lexicographic order
+		k := t - 1
+		for k >= 0 && pick[k] == len(good)-t+k {
+			k--
+		}
+		if k < 0 {
+			break
+		}
+		pick[k]++
+		for j := k + 1; j < t; j++ {
+			pick[j] = pick[j-1] + 1
+		}
+	}
+	return nil, nil, false
+}
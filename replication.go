@@ -0,0 +1,42 @@
+// replication.go
+package shamir
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SplitWithReplicationHints splits secret into len(hints) shares requiring t
+// to reconstruct and returns them as JSON envelopes (see ToJSON), share i
+// carrying hints[i] as its ReplicationHint. Storage that understands the
+// hint, such as storage.MirrorStorage, keeps that many copies of the share.
+func SplitWithReplicationHints(secret []byte, t int, hints []byte) ([][]byte, error) {
+	shares, err := Split(secret, t, len(hints))
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]byte, len(shares))
+	for i, s := range shares {
+		j, err := ShareToStruct(s)
+		wipe(s)
+		if err != nil {
+			return nil, err
+		}
+		j.ReplicationHint = hints[i]
+		if out[i], err = json.Marshal(j); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// ReplicationHint returns the replication hint of a share in JSON envelope
+// form, or 0 if it has none. Binary shares never carry a hint and fail like
+// any other non-envelope input.
+func ReplicationHint(envelope []byte) (byte, error) {
+	var j ShareJSON
+	if err := json.Unmarshal(envelope, &j); err != nil {
+		return 0, fmt.Errorf("shamir: not a JSON share envelope: %w", err)
+	}
+	return j.ReplicationHint, nil
+}
@@ -0,0 +1,100 @@
+// rng.go
+package shamir
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"io"
+	"sync"
+)
+
+// bufferedRand is an AES-256-CTR generator keyed from a source reader. It
+// hands out keystream in bufSize chunks and rekeys from the source every
+// reseedEvery refills, so most reads never touch the source.
+type bufferedRand struct {
+	mu          sync.Mutex
+	source      io.Reader
+	stream      cipher.Stream
+	buf         []byte
+	pos         int
+	fills       int
+	reseedEvery int
+}
+
+// NewBufferedRand returns a concurrency-safe CSPRNG that amortizes reads from
+// source (typically crypto/rand.Reader). Output is generated into a buffer of
+// bufSize bytes; after reseedEvery buffer refills a fresh key is drawn from
+// source. Non-positive arguments fall back to 4096 bytes and reseeding on every
+// refill.
+func NewBufferedRand(source io.Reader, bufSize int, reseedEvery int) io.Reader {
+	if bufSize <= 0 {
+		bufSize = 4096
+	}
+	if reseedEvery <= 0 {
+		reseedEvery = 1
+	}
+	buf := make([]byte, bufSize)
+	return &bufferedRand{
+		source:      source,
+		buf:         buf,
+		pos:         len(buf),
+		reseedEvery: reseedEvery,
+	}
+}
+
+// Read fills p with random bytes.
+func (b *bufferedRand) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for n < len(p) {
+		if b.pos == len(b.buf) {
+			if err := b.refill(); err != nil {
+				return n, err
+			}
+		}
+		c := copy(p[n:], b.buf[b.pos:])
+		// wipe handed-out bytes so they can't be read back from the buffer
+		for i := b.pos; i < b.pos+c; i++ {
+			b.buf[i] = 0
+		}
+		b.pos += c
+		n += c
+	}
+	return n, nil
+}
+
+// refill regenerates the buffer, rekeying from the source when due.
+func (b *bufferedRand) refill() error {
+	if b.stream == nil || b.fills%b.reseedEvery == 0 {
+		if err := b.reseed(); err != nil {
+			return err
+		}
+	}
+	for i := range b.buf {
+		b.buf[i] = 0
+	}
+	b.stream.XORKeyStream(b.buf, b.buf)
+	b.pos = 0
+	b.fills++
+	return nil
+}
+
+// reseed draws a fresh key and IV from the source.
+func (b *bufferedRand) reseed() error {
+	var seed [32 + aes.BlockSize]byte
+	defer func() {
+		for i := range seed {
+			seed[i] = 0
+		}
+	}()
+	if _, err := io.ReadFull(b.source, seed[:]); err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(seed[:32])
+	if err != nil {
+		return err
+	}
+	b.stream = cipher.NewCTR(block, seed[32:])
+	return nil
+}
@@ -0,0 +1,153 @@
+// rng_test.go
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// countingReader counts reads from an underlying source.
+type countingReader struct {
+	mu    sync.Mutex
+	r     io.Reader
+	reads int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	c.reads++
+	c.mu.Unlock()
+	return c.r.Read(p)
+}
+
+func TestBufferedRandReseeds(t *testing.T) {
+	tests := []struct {
+		name        string
+		bufSize     int
+		reseedEvery int
+		read        int
+		wantReseeds int
+	}{
+		{"defaults", 0, 0, 4096 * 3, 3},
+		{"one buffer", 64, 4, 64, 1},
+		{"reseed boundary", 64, 4, 64*4 + 1, 2},
+		{"many refills", 64, 4, 64 * 10, 3},
+		{"never within a buffer", 1024, 1, 1000, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &countingReader{r: rand.Reader}
+			r := NewBufferedRand(src, tt.bufSize, tt.reseedEvery)
+			// read in odd-sized pieces so reads straddle refills
+			got := 0
+			for got < tt.read {
+				p := make([]byte, min(37, tt.read-got))
+				n, err := r.Read(p)
+				if err != nil || n != len(p) {
+					t.Fatalf("Read = %d, %v", n, err)
+				}
+				got += n
+			}
+			if src.reads != tt.wantReseeds {
+				t.Fatalf("source read %d times, want %d", src.reads, tt.wantReseeds)
+			}
+		})
+	}
+}
+
+// TestBufferedRandNoRepeats checks that no 16-byte block of output repeats,
+// in particular across reseed boundaries, even when the source is a
+// low-quality counter.
+func TestBufferedRandNoRepeats(t *testing.T) {
+	for _, reseedEvery := range []int{1, 3} {
+		var ctr byte
+		src := readerFunc(func(p []byte) (int, error) {
+			ctr++
+			for i := range p {
+				p[i] = ctr
+			}
+			return len(p), nil
+		})
+		r := NewBufferedRand(src, 256, reseedEvery)
+		out := make([]byte, 256*20)
+		if _, err := io.ReadFull(r, out); err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[[16]byte]int)
+		for i := 0; i < len(out); i += 16 {
+			var blk [16]byte
+			copy(blk[:], out[i:])
+			if j, ok := seen[blk]; ok {
+				t.Fatalf("reseedEvery=%d: block at offset %d repeats offset %d", reseedEvery, i, j)
+			}
+			seen[blk] = i
+		}
+	}
+}
+
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
+
+func TestBufferedRandSourceError(t *testing.T) {
+	errSource := errors.New("source failed")
+	r := NewBufferedRand(readerFunc(func([]byte) (int, error) { return 0, errSource }), 64, 1)
+	if _, err := r.Read(make([]byte, 8)); !errors.Is(err, errSource) {
+		t.Fatalf("Read error = %v, want %v", err, errSource)
+	}
+}
+
+func TestBufferedRandConcurrent(t *testing.T) {
+	r := NewBufferedRand(rand.Reader, 128, 2)
+	var wg sync.WaitGroup
+	outs := make([][]byte, 8)
+	for i := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outs[i] = make([]byte, 1000)
+			if _, err := io.ReadFull(r, outs[i]); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 1; i < len(outs); i++ {
+		if bytes.Equal(outs[0], outs[i]) {
+			t.Fatalf("goroutines 0 and %d read identical output", i)
+		}
+	}
+}
+
+// BenchmarkSplitRNG compares split throughput drawing coefficients straight
+// from crypto/rand against a buffered generator.
+func BenchmarkSplitRNG(b *testing.B) {
+	secret := bytes.Repeat([]byte{0x5a}, 32)
+	scheme, err := NewScheme(3, 5)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, bc := range []struct {
+		name   string
+		scheme *Scheme
+	}{
+		{"crypto-rand", scheme},
+		{"buffered", scheme.WithRNG(NewBufferedRand(rand.Reader, 4096, 64))},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(int64(len(secret)))
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := bc.scheme.Split(secret); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -2,11 +2,13 @@
 package shamir
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"hash/crc32"
-	"sort"
+	"io"
 	"sync"
 	"time"
 )
@@ -14,17 +16,48 @@ import (
 // RotatorConfig holds parameters for share rotation.
 type RotatorConfig struct {
 	Storage          IStorage      // where shares live
+	Scheme           *Scheme       // optional; overrides Threshold and TotalShares when set
 	Threshold        int           // k
 	TotalShares      int           // n
 	RotationInterval time.Duration // how often to rotate
 	ProactiveOnly    bool          // if true, only refresh shares; if false, full secret rotation
+	Clock            Clock         // optional; defaults to SystemClock
+	CombineCache     *CombineCache // optional; reuses Lagrange weights across ticks
+
+	// SkipOnInsufficientShares makes a cycle that finds fewer than Threshold
+	// shares reachable log a warning and wait for the next interval instead
+	// of reporting an error, so a briefly unavailable backend isn't treated
+	// as a failure. nil means true.
+	SkipOnInsufficientShares *bool
 }
 
 // Rotator drives periodic rotation or refresh of Shamir shares.
 type Rotator struct {
 	cfg     RotatorConfig
+	skip    bool
 	stopCh  chan struct{}
+	errCh   chan error
 	stopped sync.WaitGroup
+
+	mu      sync.Mutex
+	running bool
+	status  RotatorStatus
+}
+
+// RotatorStatus reports the outcome of a Rotator's recent cycles.
+type RotatorStatus struct {
+	Running       bool
+	LastRotation  time.Time // end of the last successful cycle; zero if none
+	RotationCount int       // successful cycles, including any restored by LoadState
+	LastError     error     // error of the last cycle; nil if it succeeded or was skipped
+	LastErrorAt   time.Time
+}
+
+// RotatorState is the part of a Rotator's status that survives a restart;
+// see SaveState and LoadState.
+type RotatorState struct {
+	LastRotation  time.Time `json:"last_rotation"`
+	RotationCount int       `json:"rotation_count"`
 }
 
 // NewRotator constructs a Rotator.
@@ -32,73 +65,197 @@ func NewRotator(cfg RotatorConfig) (*Rotator, error) {
 	if cfg.Storage == nil {
 		return nil, errors.New("shamir/rotator: Storage cannot be nil")
 	}
+	if cfg.Scheme != nil {
+		cfg.Threshold = cfg.Scheme.Threshold()
+		cfg.TotalShares = cfg.Scheme.Total()
+	}
 	if cfg.Threshold < 2 || cfg.TotalShares < cfg.Threshold {
 		return nil, fmt.Errorf("shamir/rotator: invalid threshold/total: %d/%d", cfg.Threshold, cfg.TotalShares)
 	}
 	if cfg.RotationInterval <= 0 {
 		return nil, errors.New("shamir/rotator: RotationInterval must be > 0")
 	}
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock
+	}
+	skip := cfg.SkipOnInsufficientShares == nil || *cfg.SkipOnInsufficientShares
 	return &Rotator{
-		cfg:    cfg,
-		stopCh: make(chan struct{}),
+		cfg:   cfg,
+		skip:  skip,
+		errCh: make(chan error, 16),
 	}, nil
 }
 
 // Start begins the periodic rotation in a background goroutine.
-// It will keep running until Stop() is called.
+// It will keep running until Stop() is called. Starting a running rotator
+// does nothing; a stopped rotator can be started again.
+//
+// Once the rotator has rotated (or had its state restored by LoadState),
+// the first cycle is scheduled one RotationInterval after the last rotation
+// rather than one interval from now, and runs immediately if that time has
+// already passed.
 func (r *Rotator) Start() {
-	ticker := time.NewTicker(r.cfg.RotationInterval)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		return
+	}
+	r.running = true
+	stop := make(chan struct{})
+	r.stopCh = stop
+	period := r.cfg.RotationInterval
+	first := period
+	if last := r.status.LastRotation; !last.IsZero() {
+		first = last.Add(period).Sub(r.cfg.Clock.Now())
+	}
+	immediate := first <= 0
+	reset := !immediate && first != period
+	var ticker Ticker
+	if reset {
+		ticker = r.cfg.Clock.NewTicker(first)
+	} else {
+		ticker = r.cfg.Clock.NewTicker(period)
+	}
 	r.stopped.Add(1)
 	go func() {
 		defer func() {
 			ticker.Stop()
 			r.stopped.Done()
 		}()
+		if immediate {
+			r.handle(r.tick())
+		}
 		for {
 			select {
-			case <-ticker.C:
-				if err := r.tick(); err != nil {
-					fmt.Printf("[shamir/rotator] rotation error: %v\n", err)
+			case <-ticker.C():
+				r.handle(r.tick())
+				if reset {
+					// the shortened first wait is over; settle into the period
+					ticker.Stop()
+					ticker = r.cfg.Clock.NewTicker(period)
+					reset = false
 				}
-			case <-r.stopCh:
+			case <-stop:
 				return
 			}
 		}
 	}()
 }
 
-// Stop signals the rotator to cease and waits for cleanup.
+// SaveState writes the rotator's last rotation time and rotation count to w
+// as JSON, to be restored with LoadState after a restart.
+func (r *Rotator) SaveState(w io.Writer) error {
+	st := r.Status()
+	return json.NewEncoder(w).Encode(RotatorState{
+		LastRotation:  st.LastRotation,
+		RotationCount: st.RotationCount,
+	})
+}
+
+// LoadState restores state written by SaveState, so that Start schedules
+// the next rotation relative to the restored LastRotation. It must be called
+// before Start.
+func (r *Rotator) LoadState(rd io.Reader) error {
+	var st RotatorState
+	if err := json.NewDecoder(rd).Decode(&st); err != nil {
+		return fmt.Errorf("shamir/rotator: load state: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		return errors.New("shamir/rotator: cannot load state while running")
+	}
+	r.status.LastRotation = st.LastRotation
+	r.status.RotationCount = st.RotationCount
+	return nil
+}
+
+// Errors returns a channel receiving the error of every failed cycle.
+// Skipped cycles are not errors. The channel is buffered; errors are dropped
+// rather than stalling rotation when nobody is reading.
+func (r *Rotator) Errors() <-chan error {
+	return r.errCh
+}
+
+// Status reports whether the rotator is running and how its last cycles went.
+func (r *Rotator) Status() RotatorStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := r.status
+	st.Running = r.running
+	return st
+}
+
+// handle records the outcome of a cycle, logs it and publishes genuine errors.
+func (r *Rotator) handle(err error) {
+	now := r.cfg.Clock.Now()
+	r.mu.Lock()
+	switch {
+	case err == nil:
+		r.status.LastRotation = now
+		r.status.RotationCount++
+		r.status.LastError = nil
+	case r.skip && errors.Is(err, ErrInsufficientShares):
+		r.status.LastError = nil
+	default:
+		r.status.LastError = err
+		r.status.LastErrorAt = now
+	}
+	r.mu.Unlock()
+	if err == nil {
+		return
+	}
+	if r.skip && errors.Is(err, ErrInsufficientShares) {
+		fmt.Printf("[shamir/rotator] skipping cycle: %v\n", err)
+		return
+	}
+	fmt.Printf("[shamir/rotator] rotation error: %v\n", err)
+	select {
+	case r.errCh <- err:
+	default:
+	}
+}
+
+// Stop signals the rotator to cease and waits for cleanup. Stopping a
+// rotator that isn't running does nothing.
 func (r *Rotator) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
 	close(r.stopCh)
+	r.mu.Unlock()
 	r.stopped.Wait()
 }
 
 // tick performs one rotation or refresh cycle.
 func (r *Rotator) tick() error {
 	// 1) Load all current shares
-	idxs, err := r.cfg.Storage.ListShares()
+	idxs, err := listSharesSorted(r.cfg.Storage)
 	if err != nil {
 		return fmt.Errorf("list shares: %w", err)
 	}
 	if len(idxs) < r.cfg.Threshold {
-		return fmt.Errorf("not enough shares to operate: have %d, need %d", len(idxs), r.cfg.Threshold)
+		return fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(idxs), r.cfg.Threshold)
 	}
 
-	currentShares, err := RetrieveShares(idxs, r.cfg.Storage)
+	currentShares, err := r.retrieve(idxs)
 	if err != nil {
-		return fmt.Errorf("retrieve shares: %w", err)
+		return err
 	}
 
 	var newShares [][]byte
 	if r.cfg.ProactiveOnly {
 		// Proactive refresh: same secret, fresh shares
-		newShares, err = proactiveRefresh(currentShares, r.cfg.Threshold, r.cfg.TotalShares)
+		newShares, err = proactiveRefresh(r.cfg.CombineCache, currentShares, r.cfg.Threshold, r.cfg.TotalShares)
 		if err != nil {
 			return fmt.Errorf("proactive refresh failed: %w", err)
 		}
 	} else {
 		// Full rotation: new random secret
-		newShares, err = fullRotate(currentShares, r.cfg.Threshold, r.cfg.TotalShares)
+		newShares, err = fullRotate(r.cfg.CombineCache, r.cfg.Clock, currentShares, r.cfg.Threshold, r.cfg.TotalShares)
 		if err != nil {
 			return fmt.Errorf("full rotate failed: %w", err)
 		}
@@ -114,19 +271,77 @@ func (r *Rotator) tick() error {
 				return "refreshed shares"
 			}
 			return "rotated secret"
-		}(), time.Now().Format(time.RFC3339))
+		}(), r.cfg.Clock.Now().Format(time.RFC3339))
 	return nil
 }
 
-// fullRotate reconstructs the old secret and re-splits it without changing the secret.
-func fullRotate(oldShares [][]byte, t, n int) ([][]byte, error) {
+// retrieve reads every listed share. Every share is needed because all of
+// them are rewritten, but when the failures leave fewer than Threshold
+// shares readable the error also wraps ErrInsufficientShares.
+func (r *Rotator) retrieve(idxs []byte) ([][]byte, error) {
+	shares := make([][]byte, 0, len(idxs))
+	var errs []error
+	for _, idx := range idxs {
+		s, err := r.cfg.Storage.GetShare(idx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("share %d: %w", idx, err))
+			continue
+		}
+		shares = append(shares, s)
+	}
+	if len(errs) == 0 {
+		return shares, nil
+	}
+	err := fmt.Errorf("retrieve shares: %w", errors.Join(errs...))
+	if len(shares) < r.cfg.Threshold {
+		return nil, fmt.Errorf("%w: %d readable, need %d: %w", ErrInsufficientShares, len(shares), r.cfg.Threshold, err)
+	}
+	return nil, err
+}
+
+// fullRotate reconstructs the old secret and re-splits it without changing
+// the secret. The new shares keep the old shares' format: version, integrity
+// mode, digest, compression, recovery marker and scheme ID, with the
+// creation time taken from clock.
+func fullRotate(cc *CombineCache, clock Clock, oldShares [][]byte, t, n int) ([][]byte, error) {
 	// Combine takes first t shares automatically if len > t.
-	secret, err := Combine(oldShares)
+	secret, err := cc.Combine(oldShares)
 	if err != nil {
 		return nil, fmt.Errorf("combine old secret: %w", err)
 	}
-	// Re-split the existing secret to refresh shares.
-	newShares, err := Split(secret, t, n)
+	defer wipe(secret)
+	// Combine has verified every share has the same frame
+	first, _ := parseFrame(oldShares[0])
+	if first.integrity() == integrityHMAC {
+		return nil, errors.New("rotate: shares bound to associated data cannot be re-split")
+	}
+	if first.version == version {
+		newShares, err := Split(secret, t, n)
+		if err != nil {
+			return nil, fmt.Errorf("split new secret: %w", err)
+		}
+		return newShares, nil
+	}
+	opts := SplitOptions{
+		EmbedDigest: first.flags&flagDigest != 0,
+		SHA256Tag:   first.integrity() == integritySHA256,
+		Compress:    first.flags&flagCompressed != 0,
+	}
+	if m := first.meta(); m != nil {
+		_, opts.SchemeID = decodeMeta(m)
+		opts.CreatedAt = clock.Now()
+	}
+	f := opts.frame(secret)
+	f.version = versionV2
+	f.flags |= first.flags & flagRecovery
+	payload := secret
+	if opts.Compress {
+		if payload, err = compressSecret(secret); err != nil {
+			return nil, fmt.Errorf("split new secret: %w", err)
+		}
+		defer wipe(payload)
+	}
+	newShares, err := splitFramed(rand.Reader, payload, t, n, f, nil)
 	if err != nil {
 		return nil, fmt.Errorf("split new secret: %w", err)
 	}
@@ -134,38 +349,73 @@ func fullRotate(oldShares [][]byte, t, n int) ([][]byte, error) {
 }
 
 // proactiveRefresh keeps the same secret but churns share values.
-func proactiveRefresh(oldShares [][]byte, t, n int) ([][]byte, error) {
-	// Sort oldShares by share index (stored at offset 9) to align with zeroShares order.
-	sort.Slice(oldShares, func(i, j int) bool {
-		return oldShares[i][9] < oldShares[j][9]
-	})
+// cc may be nil.
+func proactiveRefresh(cc *CombineCache, oldShares [][]byte, t, n int) ([][]byte, error) {
+	if err := checkRefreshInput(oldShares, n); err != nil {
+		return nil, err
+	}
+	// Sort oldShares by share index so the output order is stable.
+	oldShares = sortByIndex(oldShares)
 	// Combine to verify secret consistency but discard result
-	if _, err := Combine(oldShares); err != nil {
+	if _, err := cc.Combine(oldShares); err != nil {
 		return nil, fmt.Errorf("combine for refresh: %w", err)
 	}
 	// generate a zero-secret share set (all zeros)
-	zero := make([]byte, len(oldShares[0])-(4+1+1+1+2+1+4))
+	secretLen := int(binary.BigEndian.Uint16(oldShares[0][7:9]))
+	// checkRefreshInput guarantees every share has the same frame
+	first, _ := parseFrame(oldShares[0])
+	off := first.headerLen()
+	zero := make([]byte, secretLen)
 	zeroShares, err := Split(zero, t, n)
 	if err != nil {
 		return nil, fmt.Errorf("split zero: %w", err)
 	}
 	// XOR (add in GF(2^8)) old payload with zeroShares payload bytewise
-	headLen := 4 + 1 + 1 + 1 + 2 + 1
-	refreshed := make([][]byte, n)
-	for i := 0; i < n; i++ {
-		a := oldShares[i]
-		b := zeroShares[i]
+	refreshed := make([][]byte, len(oldShares))
+	for i, a := range oldShares {
+		// zeroShares[k] carries index k+1
+		b := zeroShares[a[9]-1]
+		// copy header (and any v2 extension fields) unchanged
 		sum := make([]byte, len(a))
-		// copy header
-		copy(sum[:headLen], a[:headLen])
-		// compute new payload (unchanged because b[j]==0)
-		for j := headLen; j < len(a)-4; j++ {
-			sum[j] = a[j] ^ b[j]
+		copy(sum, a)
+		// the zero polynomial leaves the secret unchanged at x=0
+		for j := 0; j < secretLen; j++ {
+			sum[off+j] ^= b[headLen+j]
 		}
-		// recalc CRC32
-		crc := crc32.ChecksumIEEE(sum[:len(sum)-4])
-		binary.BigEndian.PutUint32(sum[len(sum)-4:], crc)
+		// recalc the CRC32 or SHA-256 tag
+		sealShare(sum, nil)
 		refreshed[i] = sum
 	}
 	return refreshed, nil
 }
+
+// checkRefreshInput makes sure every old share has the same length and header
+// before the refresh polynomial is sized from the first one.
+func checkRefreshInput(oldShares [][]byte, n int) error {
+	if len(oldShares) == 0 {
+		return errors.New("refresh: no shares provided")
+	}
+	first, err := parseFrame(oldShares[0])
+	if err != nil {
+		return fmt.Errorf("refresh: share 0: %w", err)
+	}
+	if first.integrity() == integrityHMAC {
+		return errors.New("refresh: shares bound to associated data cannot be refreshed")
+	}
+	hl := first.headerLen()
+	for i, s := range oldShares {
+		if len(s) != len(oldShares[0]) {
+			return fmt.Errorf("refresh: share %d has length %d, expected %d", i, len(s), len(oldShares[0]))
+		}
+		if !bytes.Equal(s[0:5], oldShares[0][0:5]) {
+			return fmt.Errorf("refresh: share %d has mismatched magic or version", i)
+		}
+		if !bytes.Equal(s[5:9], oldShares[0][5:9]) || !bytes.Equal(s[headLen:hl], oldShares[0][headLen:hl]) {
+			return fmt.Errorf("refresh: share %d has mismatched header fields", i)
+		}
+		if s[9] == 0 || int(s[9]) > n {
+			return fmt.Errorf("refresh: share %d has index %d outside 1..%d", i, s[9], n)
+		}
+	}
+	return nil
+}
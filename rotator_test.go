@@ -0,0 +1,345 @@
+// rotator_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestRotator stores a fresh 2-of-3 split of secret in st and returns a
+// rotator over it driven by clock.
+func newTestRotator(t *testing.T, st IStorage, clock Clock, secret []byte) *Rotator {
+	t.Helper()
+	shares, err := Split(secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := StoreShares(shares, st); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRotator(RotatorConfig{
+		Storage:          st,
+		Threshold:        2,
+		TotalShares:      3,
+		RotationInterval: time.Hour,
+		Clock:            clock,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func storedShares(t *testing.T, st IStorage) [][]byte {
+	t.Helper()
+	idxs, err := st.ListShares()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out [][]byte
+	for _, idx := range idxs {
+		s, err := st.GetShare(idx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestRotatorFakeClock(t *testing.T) {
+	secret := []byte("rotated by a fake clock")
+	st := newMapStorage()
+	clock := newFakeClock(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := newTestRotator(t, st, clock, secret)
+	r.Start()
+	defer r.Stop()
+
+	before := storedShares(t, st)
+	clock.Advance(59 * time.Minute)
+	if n := r.Status().RotationCount; n != 0 {
+		t.Fatalf("rotated %d times before the interval elapsed", n)
+	}
+	clock.Advance(time.Minute)
+	for i := 1; i <= 3; i++ {
+		waitFor(t, "rotation", func() bool { return r.Status().RotationCount == i })
+		if i < 3 {
+			clock.Advance(time.Hour)
+		}
+	}
+
+	st2 := r.Status()
+	if !st2.Running || st2.LastError != nil {
+		t.Fatalf("status = %+v", st2)
+	}
+	if !st2.LastRotation.Equal(clock.Now()) {
+		t.Fatalf("LastRotation = %v, want %v", st2.LastRotation, clock.Now())
+	}
+	after := storedShares(t, st)
+	if bytes.Equal(before[0], after[0]) {
+		t.Fatal("shares were not rewritten")
+	}
+	got, err := Combine(after)
+	if err != nil || !bytes.Equal(got, secret) {
+		t.Fatalf("Combine after rotation = %q, %v", got, err)
+	}
+
+	r.Stop()
+	if r.Status().Running {
+		t.Fatal("still running after Stop")
+	}
+	if active := clock.Active(); len(active) != 0 {
+		t.Fatalf("tickers left running after Stop: %v", active)
+	}
+}
+
+func TestRotatorRestoredSchedule(t *testing.T) {
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		downtime time.Duration // time between the saved rotation and the restart
+		wait     time.Duration // time after the restart until the first rotation
+	}{
+		{"part of the interval left", 20 * time.Minute, 40 * time.Minute},
+		{"restart right after rotating", 0, time.Hour},
+		{"interval already overdue", 3 * time.Hour, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newMapStorage()
+			clock := newFakeClock(start)
+			r := newTestRotator(t, st, clock, []byte("restored"))
+			r.Start()
+			clock.Advance(time.Hour)
+			waitFor(t, "first rotation", func() bool { return r.Status().RotationCount == 1 })
+			r.Stop()
+			var state bytes.Buffer
+			if err := r.SaveState(&state); err != nil {
+				t.Fatal(err)
+			}
+			saved := r.Status().LastRotation
+
+			clock.Advance(tt.downtime)
+			restarted, err := NewRotator(r.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := restarted.LoadState(&state); err != nil {
+				t.Fatal(err)
+			}
+			if got := restarted.Status(); !got.LastRotation.Equal(saved) || got.RotationCount != 1 {
+				t.Fatalf("restored status = %+v, want LastRotation %v and 1 rotation", got, saved)
+			}
+			restarted.Start()
+			defer restarted.Stop()
+
+			if tt.wait > 0 {
+				clock.Advance(tt.wait - time.Second)
+				if n := restarted.Status().RotationCount; n != 1 {
+					t.Fatalf("rotated %d times before %v after the restart", n-1, tt.wait)
+				}
+				clock.Advance(time.Second)
+			}
+			waitFor(t, "rotation after restart", func() bool { return restarted.Status().RotationCount == 2 })
+			if got, want := restarted.Status().LastRotation, saved.Add(tt.downtime+tt.wait); !got.Equal(want) {
+				t.Fatalf("LastRotation = %v, want %v", got, want)
+			}
+
+			// later rotations follow the regular interval from there
+			waitFor(t, "regular ticker", func() bool {
+				a := clock.Active()
+				return len(a) == 1 && a[0] == time.Hour
+			})
+			clock.Advance(time.Hour)
+			waitFor(t, "next rotation", func() bool { return restarted.Status().RotationCount == 3 })
+		})
+	}
+}
+
+func TestRotatorLoadStateWhileRunning(t *testing.T) {
+	r := newTestRotator(t, newMapStorage(), newFakeClock(time.Unix(0, 0)), []byte("busy"))
+	r.Start()
+	defer r.Stop()
+	if err := r.LoadState(bytes.NewBufferString(`{"rotation_count":4}`)); err == nil {
+		t.Fatal("LoadState succeeded on a running rotator")
+	}
+	if err := r.LoadState(bytes.NewBufferString(`not json`)); err == nil {
+		t.Fatal("LoadState accepted malformed state")
+	}
+}
+
+// outageStorage fails reads of the shares in down, or every listing when
+// listErr is set.
+type outageStorage struct {
+	*mapStorage
+	mu      sync.Mutex
+	down    map[byte]bool
+	listErr error
+	failed  int // reads refused so far
+}
+
+func (o *outageStorage) set(down map[byte]bool, listErr error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.down, o.listErr = down, listErr
+}
+
+func (o *outageStorage) GetShare(index byte) ([]byte, error) {
+	o.mu.Lock()
+	down := o.down[index]
+	if down {
+		o.failed++
+	}
+	o.mu.Unlock()
+	if down {
+		return nil, errors.New("backend unavailable")
+	}
+	return o.mapStorage.GetShare(index)
+}
+
+func (o *outageStorage) ListShares() ([]byte, error) {
+	o.mu.Lock()
+	err := o.listErr
+	o.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return o.mapStorage.ListShares()
+}
+
+func TestRotatorQuorumLoss(t *testing.T) {
+	errList := errors.New("list failed")
+	no, yes := false, true
+	tests := []struct {
+		name    string
+		skip    *bool
+		down    map[byte]bool
+		listErr error
+		wantErr error // reported on Errors; nil means the cycle is skipped
+	}{
+		{"default skips", nil, map[byte]bool{1: true, 3: true}, nil, nil},
+		{"explicit skip", &yes, map[byte]bool{2: true, 3: true}, nil, nil},
+		{"skip disabled", &no, map[byte]bool{1: true, 2: true}, nil, ErrInsufficientShares},
+		{"genuine error still reported", nil, nil, errList, errList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &outageStorage{mapStorage: newMapStorage()}
+			clock := newFakeClock(time.Unix(0, 0))
+			r := newTestRotator(t, st, clock, []byte("quorum"))
+			r.cfg.SkipOnInsufficientShares = tt.skip
+			r, err := NewRotator(r.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			st.set(tt.down, tt.listErr)
+			r.Start()
+			defer r.Stop()
+			clock.Advance(time.Hour)
+
+			if tt.wantErr != nil {
+				select {
+				case err := <-r.Errors():
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("Errors() got %v, want %v", err, tt.wantErr)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("no error reported")
+				}
+				waitFor(t, "status", func() bool { return r.Status().LastError != nil })
+				if !r.Status().LastErrorAt.Equal(clock.Now()) {
+					t.Fatalf("LastErrorAt = %v, want %v", r.Status().LastErrorAt, clock.Now())
+				}
+				return
+			}
+
+			waitFor(t, "skipped cycle", func() bool {
+				st.mu.Lock()
+				defer st.mu.Unlock()
+				return st.failed == len(tt.down)
+			})
+			// the backend recovers; the next cycle rotates
+			st.set(nil, nil)
+			clock.Advance(time.Hour)
+			waitFor(t, "rotation after recovery", func() bool { return r.Status().RotationCount == 1 })
+			select {
+			case err := <-r.Errors():
+				t.Fatalf("skipped cycle reported %v", err)
+			default:
+			}
+			if got := r.Status(); got.LastError != nil || !got.LastErrorAt.IsZero() {
+				t.Fatalf("status after skipped cycle = %+v", got)
+			}
+		})
+	}
+}
+
+func TestProactiveRefreshInput(t *testing.T) {
+	shares, err := Split([]byte("refresh me"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Split([]byte("a longer secret"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, err := SplitWithOptions([]byte("refresh me"), 2, 3, SplitOptions{AAD: []byte("ctx")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	padded := append(bytes.Clone(shares[1]), 0)
+	tests := []struct {
+		name   string
+		shares [][]byte
+		n      int
+	}{
+		{"no shares", nil, 3},
+		{"mismatched length", [][]byte{shares[0], padded, shares[2]}, 3},
+		{"other secret length", [][]byte{shares[0], other[1]}, 3},
+		{"mismatched total", [][]byte{shares[0], edited(shares[1], true, func(b []byte) []byte { b[6] = 4; return b })}, 3},
+		{"index above n", [][]byte{shares[0], shares[2]}, 2},
+		{"bad magic", [][]byte{edited(shares[0], false, func(b []byte) []byte { b[0] = 'X'; return b }), shares[1]}, 3},
+		{"bound to AAD", bound, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := proactiveRefresh(nil, tt.shares, 2, tt.n); err == nil {
+				t.Fatal("proactiveRefresh accepted bad input")
+			}
+		})
+	}
+}
+
+func TestProactiveRefreshZeroSecret(t *testing.T) {
+	for _, secret := range [][]byte{make([]byte, 1), make([]byte, 32)} {
+		shares, err := Split(secret, 3, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		refreshed, err := proactiveRefresh(nil, shares, 3, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(refreshed) != len(shares) {
+			t.Fatalf("got %d shares, want %d", len(refreshed), len(shares))
+		}
+		for i := range shares {
+			if bytes.Equal(refreshed[i], shares[i]) {
+				t.Fatalf("share %d unchanged by refresh", i+1)
+			}
+			if err := ValidateShare(refreshed[i]); err != nil {
+				t.Fatalf("refreshed share %d: %v", i+1, err)
+			}
+		}
+		// any quorum of the refreshed shares still yields the zero secret
+		for _, pick := range [][]int{{0, 1, 2}, {2, 3, 4}, {0, 2, 4}} {
+			got, err := Combine([][]byte{refreshed[pick[0]], refreshed[pick[1]], refreshed[pick[2]]})
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Fatalf("Combine %v = %x, %v; want %x", pick, got, err, secret)
+			}
+		}
+	}
+}
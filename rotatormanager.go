@@ -0,0 +1,143 @@
+// rotatormanager.go
+package shamir
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RotatorManager holds named rotators so a service rotating many secrets can
+// start, stop and monitor them together. It is safe for concurrent use.
+type RotatorManager struct {
+	mu       sync.Mutex
+	rotators map[string]*Rotator
+}
+
+// NewRotatorManager returns an empty manager.
+func NewRotatorManager() *RotatorManager {
+	return &RotatorManager{rotators: make(map[string]*Rotator)}
+}
+
+// Add builds a rotator from cfg and registers it under name. It is not
+// started until StartAll or Start is called.
+func (m *RotatorManager) Add(name string, cfg RotatorConfig) error {
+	r, err := NewRotator(cfg)
+	if err != nil {
+		return fmt.Errorf("shamir/rotator: %q: %w", name, err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.rotators[name]; ok {
+		return fmt.Errorf("shamir/rotator: %q is already registered", name)
+	}
+	m.rotators[name] = r
+	return nil
+}
+
+// Remove stops the named rotator and unregisters it.
+func (m *RotatorManager) Remove(name string) error {
+	m.mu.Lock()
+	r, ok := m.rotators[name]
+	delete(m.rotators, name)
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("shamir/rotator: no rotator named %q", name)
+	}
+	r.Stop()
+	return nil
+}
+
+// Names returns the registered names in sorted order.
+func (m *RotatorManager) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.rotators))
+	for name := range m.rotators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start starts the named rotator.
+func (m *RotatorManager) Start(name string) error {
+	r, err := m.get(name)
+	if err != nil {
+		return err
+	}
+	r.Start()
+	return nil
+}
+
+// Stop stops the named rotator and waits for its current cycle to finish.
+func (m *RotatorManager) Stop(name string) error {
+	r, err := m.get(name)
+	if err != nil {
+		return err
+	}
+	r.Stop()
+	return nil
+}
+
+// StartAll starts every registered rotator that isn't already running.
+func (m *RotatorManager) StartAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.rotators {
+		r.Start()
+	}
+}
+
+// StopAll stops every running rotator and waits for them all to finish.
+func (m *RotatorManager) StopAll() {
+	m.mu.Lock()
+	rs := make([]*Rotator, 0, len(m.rotators))
+	for _, r := range m.rotators {
+		rs = append(rs, r)
+	}
+	m.mu.Unlock()
+	var wg sync.WaitGroup
+	for _, r := range rs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Stop()
+		}()
+	}
+	wg.Wait()
+}
+
+// Status reports the status of the named rotator.
+func (m *RotatorManager) Status(name string) (RotatorStatus, error) {
+	r, err := m.get(name)
+	if err != nil {
+		return RotatorStatus{}, err
+	}
+	return r.Status(), nil
+}
+
+// Err joins the last-cycle error of every rotator whose most recent cycle
+// failed, each prefixed with its name, or returns nil if none did.
+func (m *RotatorManager) Err() error {
+	var errs []error
+	for _, name := range m.Names() {
+		st, err := m.Status(name)
+		if err != nil || st.LastError == nil {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", name, st.LastError))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *RotatorManager) get(name string) (*Rotator, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.rotators[name]
+	if !ok {
+		return nil, fmt.Errorf("shamir/rotator: no rotator named %q", name)
+	}
+	return r, nil
+}
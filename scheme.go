@@ -0,0 +1,95 @@
+// scheme.go
+package shamir
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// Scheme holds a validated (t, n) pair so callers don't have to pass and
+// re-check the parameters on every split.
+type Scheme struct {
+	threshold int
+	total     int
+	rng       io.Reader // nil means crypto/rand.Reader
+}
+
+// NewScheme validates t and n once and returns a reusable Scheme.
+func NewScheme(t, n int) (*Scheme, error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, err
+	}
+	return &Scheme{threshold: t, total: n}, nil
+}
+
+// Threshold returns the number of shares needed to reconstruct.
+func (s *Scheme) Threshold() int {
+	return s.threshold
+}
+
+// Total returns the number of shares produced by Split.
+func (s *Scheme) Total() int {
+	return s.total
+}
+
+// WithRNG returns a copy of the scheme that draws coefficients from rng,
+// e.g. a reader from NewBufferedRand. The reader must be safe for concurrent
+// use if the scheme is shared between goroutines.
+func (s *Scheme) WithRNG(rng io.Reader) *Scheme {
+	c := *s
+	c.rng = rng
+	return &c
+}
+
+// Split splits the secret into the scheme's n shares.
+func (s *Scheme) Split(secret []byte) ([][]byte, error) {
+	rng := s.rng
+	if rng == nil {
+		rng = rand.Reader
+	}
+	return s.SplitWithReader(rng, secret)
+}
+
+// SplitWithReader splits the secret using a custom RNG.
+func (s *Scheme) SplitWithReader(rng io.Reader, secret []byte) ([][]byte, error) {
+	return split(rng, secret, s.threshold, s.total)
+}
+
+// Combine reconstructs the secret, rejecting shares produced by a different scheme.
+func (s *Scheme) Combine(shares [][]byte) ([]byte, error) {
+	for _, sh := range shares {
+		if len(sh) < headLen {
+			return nil, fmt.Errorf("%w: share too short", ErrLengthMismatch)
+		}
+		if int(sh[5]) != s.threshold || int(sh[6]) != s.total {
+			return nil, fmt.Errorf("%w: share %d does not match scheme parameters", ErrHeaderMismatch, sh[9])
+		}
+	}
+	return Combine(shares)
+}
+
+// Authorize retrieves the given indices from storage and combines them
+// using the scheme's threshold.
+func (s *Scheme) Authorize(st IStorage, indices []byte) ([]byte, error) {
+	return MultiPartyAuthorize(st, indices, s.threshold)
+}
+
+// ChangeScheme re-splits the secret held by shares under a new threshold
+// and share count, e.g. moving from 3-of-5 to 2-of-4. At least the old
+// threshold of shares is required. The old shares stay valid for the old
+// secret, so they must be destroyed once the new ones are distributed.
+//
+// The secret is reconstructed in plaintext in this process's memory for the
+// duration of the call (it is wiped afterwards), so the caller must be
+// trusted with it; this is not a distributed reshare. The new shares keep
+// the format of the old ones (version, integrity mode, digest, compression,
+// recovery marker and scheme ID), with the creation time set to now. Shares
+// bound to associated data are rejected, since the secret can't be
+// recovered from them without it.
+func ChangeScheme(shares [][]byte, newT, newN int) ([][]byte, error) {
+	if err := validateParams(newT, newN); err != nil {
+		return nil, err
+	}
+	return fullRotate(nil, SystemClock, shares, newT, newN)
+}
@@ -0,0 +1,125 @@
+// scheme_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChangeSchemeKeepsFormat(t *testing.T) {
+	secret := bytes.Repeat([]byte("rotate me "), 8)
+	split := func(opts SplitOptions) func() ([][]byte, error) {
+		return func() ([][]byte, error) { return SplitWithOptions(secret, 2, 3, opts) }
+	}
+	tests := []struct {
+		name  string
+		split func() ([][]byte, error)
+	}{
+		{"v1", func() ([][]byte, error) { return Split(secret, 2, 3) }},
+		{"digest", split(SplitOptions{EmbedDigest: true})},
+		{"sha256", split(SplitOptions{SHA256Tag: true})},
+		{"meta", split(SplitOptions{SchemeID: 42})},
+		{"compressed", split(SplitOptions{Compress: true})},
+		{"recovery", func() ([][]byte, error) {
+			_, rec, err := SplitWithRecovery(secret, 2, 3, 2, 3)
+			return rec, err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, err := tt.split()
+			if err != nil {
+				t.Fatal(err)
+			}
+			shares, err := ChangeScheme(old, 3, 5)
+			if err != nil {
+				t.Fatalf("ChangeScheme: %v", err)
+			}
+			before, _ := parseFrame(old[0])
+			for _, s := range shares {
+				after, err := parseShare(s)
+				if err != nil {
+					t.Fatalf("new share: %v", err)
+				}
+				if after.version != before.version || after.flags != before.flags {
+					t.Fatalf("frame = v%d flags %#x, want v%d flags %#x", after.version, after.flags, before.version, before.flags)
+				}
+			}
+			if IsRecoveryShare(old[0]) != IsRecoveryShare(shares[0]) {
+				t.Fatal("recovery marker not preserved")
+			}
+			if bm, am := before.meta(), mustFrame(t, shares[0]).meta(); bm != nil {
+				_, bid := decodeMeta(bm)
+				_, aid := decodeMeta(am)
+				if aid != bid {
+					t.Fatalf("scheme ID = %d, want %d", aid, bid)
+				}
+			}
+			got, err := Combine(shares[:3])
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Fatalf("Combine = %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestFullRotateUsesClock(t *testing.T) {
+	old, err := SplitWithOptions([]byte("stamped"), 2, 3, SplitOptions{SchemeID: 7, CreatedAt: time.Unix(1_000_000, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := newFakeClock(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))
+	shares, err := fullRotate(nil, clock, old, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range shares {
+		created, err := ShareCreatedAt(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !created.Equal(clock.Now()) {
+			t.Fatalf("CreatedAt = %v, want the clock's %v", created, clock.Now())
+		}
+	}
+}
+
+func TestChangeSchemeRejectsAADShares(t *testing.T) {
+	old, err := SplitWithOptions([]byte("bound"), 2, 3, SplitOptions{AAD: []byte("tenant")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ChangeScheme(old, 2, 4); !errors.Is(err, ErrAADRequired) {
+		t.Fatalf("ChangeScheme on AAD shares: %v, want ErrAADRequired", err)
+	}
+}
+
+func TestBreakGlassAfterChangeScheme(t *testing.T) {
+	_, rec, err := SplitWithRecovery([]byte("break glass"), 2, 3, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err = ChangeScheme(rec, 2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := newMapStorage()
+	if err := StoreShares(rec, st); err != nil {
+		t.Fatal(err)
+	}
+	got, err := BreakGlassRecovery(st, []byte{1, 4}, 2)
+	if err != nil || string(got) != "break glass" {
+		t.Fatalf("BreakGlassRecovery = %q, %v", got, err)
+	}
+}
+
+func mustFrame(t *testing.T, share []byte) shareInfo {
+	t.Helper()
+	info, err := parseFrame(share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
@@ -0,0 +1,94 @@
+// selftest.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ErrSelfTest is returned by SelfTest when the field arithmetic is broken.
+var ErrSelfTest = errors.New("shamir: self-test failed")
+
+// Known-answer vector: a 2-byte secret split 3-of-3 with fixed coefficients.
+var (
+	katSecret = []byte{0x53, 0x48}
+	katCoeffs = []byte{0xca, 0x01, 0x17, 0xfe} // per byte: a1, a2
+	katShares = [][]byte{{0x98, 0xa1}, {0xd8, 0xb3}, {0x13, 0x5a}}
+)
+
+// SelfTest checks the GF(256) tables against the field axioms and runs a
+// known-answer split and combine. Services that want to fail fast on a
+// miscompiled or corrupted build can call it at startup; it takes well under
+// a millisecond.
+func SelfTest() error {
+	if err := checkTables(&expTable, &logTable); err != nil {
+		return err
+	}
+	return knownAnswer()
+}
+
+// checkTables validates a pair of exp/log tables without relying on them for
+// the reference arithmetic.
+func checkTables(exp *[512]byte, log *[256]byte) error {
+	tmul := func(a, b byte) byte {
+		if a == 0 || b == 0 {
+			return 0
+		}
+		return exp[int(log[a])+int(log[b])]
+	}
+	// FIPS-197 section 4.2 example.
+	if got := tmul(0x57, 0x83); got != 0xc1 {
+		return fmt.Errorf("%w: 0x57*0x83 = %#02x, want 0xc1", ErrSelfTest, got)
+	}
+	for i := 0; i < 512; i++ {
+		if exp[i] != exp[i%255] {
+			return fmt.Errorf("%w: exp[%d] is not periodic", ErrSelfTest, i)
+		}
+	}
+	for x := 1; x < 256; x++ {
+		a := byte(x)
+		if exp[log[a]] != a {
+			return fmt.Errorf("%w: exp[log[%#02x]] != %#02x", ErrSelfTest, a, a)
+		}
+		ia := exp[255-int(log[a])]
+		if tmul(a, ia) != 1 {
+			return fmt.Errorf("%w: %#02x * inv(%#02x) != 1", ErrSelfTest, a, a)
+		}
+		// Compare against the table-free multiplication on a spread of operands.
+		for _, b := range [...]byte{0x02, 0x03, 0x1b, 0x80, 0xfe, byte(x * 7)} {
+			if tmul(a, b) != gfMulNoLUT(a, b) {
+				return fmt.Errorf("%w: %#02x*%#02x disagrees with reference", ErrSelfTest, a, b)
+			}
+		}
+	}
+	for _, v := range [...][3]byte{{0x03, 0x57, 0x83}, {0xff, 0x01, 0xfe}, {0x8d, 0x1b, 0xe5}} {
+		a, b, c := v[0], v[1], v[2]
+		if tmul(a, b^c) != tmul(a, b)^tmul(a, c) {
+			return fmt.Errorf("%w: distributivity fails for %#02x, %#02x, %#02x", ErrSelfTest, a, b, c)
+		}
+	}
+	return nil
+}
+
+// knownAnswer splits katSecret with fixed coefficients and checks the
+// payloads and the reconstructed secret.
+func knownAnswer() error {
+	shares, err := SplitWithReader(bytes.NewReader(katCoeffs), katSecret, 3, 3)
+	if err != nil {
+		return fmt.Errorf("%w: split: %w", ErrSelfTest, err)
+	}
+	for i, s := range shares {
+		if !bytes.Equal(s[headLen:headLen+len(katSecret)], katShares[i]) {
+			return fmt.Errorf("%w: share %d payload mismatch", ErrSelfTest, i+1)
+		}
+	}
+	got, err := Combine([][]byte{shares[2], shares[0], shares[1]})
+	if err != nil {
+		return fmt.Errorf("%w: combine: %w", ErrSelfTest, err)
+	}
+	if !bytes.Equal(got, katSecret) {
+		return fmt.Errorf("%w: combine returned wrong secret", ErrSelfTest)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// selftest_test.go
+package shamir
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckTablesDetectsCorruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(exp *[512]byte, log *[256]byte)
+	}{
+		{"exp low byte", func(exp *[512]byte, _ *[256]byte) { exp[0] ^= 0x01 }},
+		{"exp mid table", func(exp *[512]byte, _ *[256]byte) { exp[100] ^= 0x40 }},
+		{"exp duplicated half", func(exp *[512]byte, _ *[256]byte) { exp[300] ^= 0x80 }},
+		{"exp last byte", func(exp *[512]byte, _ *[256]byte) { exp[511] ^= 0x10 }},
+		{"log of 0x57", func(_ *[512]byte, log *[256]byte) { log[0x57] ^= 0x02 }},
+		{"log of 0xff", func(_ *[512]byte, log *[256]byte) { log[0xff] ^= 0x01 }},
+		{"swapped exp entries", func(exp *[512]byte, _ *[256]byte) {
+			exp[3], exp[4] = exp[4], exp[3]
+			exp[258], exp[259] = exp[259], exp[258]
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp, log := expTable, logTable
+			tt.corrupt(&exp, &log)
+			if err := checkTables(&exp, &log); !errors.Is(err, ErrSelfTest) {
+				t.Fatalf("checkTables error = %v, want ErrSelfTest", err)
+			}
+		})
+	}
+}
+
+// TestCheckTablesEveryBitFlip flips each bit of every table entry that is
+// ever read; log[0] is never used.
+func TestCheckTablesEveryBitFlip(t *testing.T) {
+	for i := range expTable {
+		for bit := 0; bit < 8; bit++ {
+			exp, log := expTable, logTable
+			exp[i] ^= 1 << bit
+			if checkTables(&exp, &log) == nil {
+				t.Fatalf("flipping bit %d of exp[%d] went undetected", bit, i)
+			}
+		}
+	}
+	for i := 1; i < len(logTable); i++ {
+		for bit := 0; bit < 8; bit++ {
+			exp, log := expTable, logTable
+			log[i] ^= 1 << bit
+			if checkTables(&exp, &log) == nil {
+				t.Fatalf("flipping bit %d of log[%d] went undetected", bit, i)
+			}
+		}
+	}
+	if exp, log := expTable, logTable; checkTables(&exp, &log) != nil {
+		t.Fatal("the copied tables themselves fail")
+	}
+}
@@ -3,12 +3,12 @@ package shamir
 import (
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"hash/crc32"
+	"fmt"
 	"io"
+	"sort"
 	"sync"
 )
 
@@ -100,7 +100,16 @@ type ShareJSON struct {
 	Index       byte   `json:"index"`
 	Threshold   byte   `json:"threshold"`
 	TotalShares byte   `json:"total_shares"`
-	Data        string `json:"data"` // base64-encoded payload
+	Data        string `json:"data"`              // base64-encoded payload
+	Version     byte   `json:"version,omitempty"` // 0 or 1 means the v1 format
+	Flags       byte   `json:"flags,omitempty"`   // v2 header flags
+	Ext         string `json:"ext,omitempty"`     // base64-encoded v2 extension fields
+	Tag         string `json:"tag,omitempty"`     // base64-encoded keyed integrity tag (HMAC mode only)
+
+	// ReplicationHint is the dealer's suggested number of stores to keep
+	// copies of this share in, honoured by storage.MirrorStorage. Zero means
+	// no hint. It lives only in the envelope; the binary share is unchanged.
+	ReplicationHint byte `json:"replication_hint,omitempty"`
 }
 
 // Split splits the secret into n shares requiring t to reconstruct.
@@ -110,23 +119,52 @@ func Split(secret []byte, t, n int) ([][]byte, error) {
 
 // SplitWithReader allows custom RNG (for testing).
 func SplitWithReader(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, err
+	}
+	return split(rng, secret, t, n)
+}
+
+// validateParams checks the (t, n) bounds shared by every split.
+func validateParams(t, n int) error {
 	if t < 2 || t > 255 {
-		return nil, errors.New("shamir: threshold must be between 2 and 255")
+		return fmt.Errorf("%w: threshold must be between 2 and 255", ErrInvalidParams)
 	}
 	if n < t || n > 255 {
-		return nil, errors.New("shamir: number of shares must be between threshold and 255")
+		return fmt.Errorf("%w: number of shares must be between threshold and 255", ErrInvalidParams)
 	}
+	return nil
+}
+
+// split does the work of SplitWithReader on already validated parameters.
+func split(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
+	return splitFramed(rng, secret, t, n, v1Frame, nil)
+}
+
+// splitFramed splits secret into n shares using the given header frame.
+// aad keys the tag of frames in HMAC mode and is otherwise ignored.
+func splitFramed(rng io.Reader, secret []byte, t, n int, f frame, aad []byte) ([][]byte, error) {
 	secretLen := len(secret)
+	if secretLen > 0xFFFF {
+		return nil, fmt.Errorf("%w: secret longer than 65535 bytes", ErrInvalidParams)
+	}
 	shares := make([][]byte, n)
 	for i := range shares {
-		buf := make([]byte, headLen+secretLen+4) // +4 for CRC32
-		copy(buf[0:], magicHeader)
-		buf[4] = version
-		buf[5] = byte(t)
-		buf[6] = byte(n)
-		binary.BigEndian.PutUint16(buf[7:], uint16(secretLen))
-		buf[9] = byte(i + 1) // index from 1..n
-		shares[i] = buf
+		shares[i] = make([]byte, f.headerLen()+secretLen+f.tagLen())
+	}
+	if err := splitFramedInto(rng, secret, t, n, f, aad, shares); err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// splitFramedInto does the work of splitFramed, writing into shares, which
+// must hold n buffers of exactly the framed share size.
+func splitFramedInto(rng io.Reader, secret []byte, t, n int, f frame, aad []byte, shares [][]byte) error {
+	secretLen := len(secret)
+	hl := f.headerLen()
+	for i, buf := range shares {
+		writeHeader(buf, f, byte(t), byte(n), byte(i+1), secretLen) // index from 1..n
 	}
 	// for each secret byte, build polynomial and evaluate
 	for j := 0; j < secretLen; j++ {
@@ -134,7 +172,11 @@ func SplitWithReader(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
 		coeffs := (*pb)[:t]
 		coeffs[0] = secret[j]
 		if _, err := io.ReadFull(rng, coeffs[1:]); err != nil {
-			return nil, err
+			for k := range coeffs {
+				coeffs[k] = 0
+			}
+			coeffPool.Put(pb)
+			return err
 		}
 		for i := 0; i < n; i++ {
 			x := shares[i][9]
@@ -144,7 +186,7 @@ func SplitWithReader(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
 				px = mul(px, x)
 				y ^= mul(coeffs[k], px)
 			}
-			shares[i][headLen+j] = y
+			shares[i][hl+j] = y
 		}
 		// zero out and return buffer
 		for k := range coeffs {
@@ -152,37 +194,142 @@ func SplitWithReader(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
 		}
 		coeffPool.Put(pb)
 	}
-	// append CRC32
+	// append CRC32 (or HMAC)
 	for _, buf := range shares {
-		d := buf[:headLen+secretLen]
-		crc := crc32.ChecksumIEEE(d)
-		binary.BigEndian.PutUint32(buf[len(buf)-4:], crc)
+		sealShare(buf, aad)
 	}
+	return nil
+}
 
-	return shares, nil
+// ShareSize returns the size of each share Split produces for a secret of
+// secretLen bytes: headLen (10) + secretLen + 4. Use it to size the buffers
+// passed to SplitInto.
+func ShareSize(secretLen int) int {
+	return v1Frame.headerLen() + secretLen + v1Frame.tagLen()
+}
+
+// SplitInto is SplitWithReader writing the n shares into caller-provided
+// buffers instead of allocating them, for hot paths splitting many small
+// secrets. dst must hold exactly n buffers of exactly ShareSize(len(secret))
+// bytes each; they are overwritten entirely. On error the contents of dst
+// are unspecified.
+func SplitInto(rng io.Reader, secret []byte, t, n int, dst [][]byte) error {
+	if err := validateParams(t, n); err != nil {
+		return err
+	}
+	if len(secret) > 0xFFFF {
+		return fmt.Errorf("%w: secret longer than 65535 bytes", ErrInvalidParams)
+	}
+	if len(dst) != n {
+		return fmt.Errorf("%w: %d destination buffers for %d shares", ErrInvalidParams, len(dst), n)
+	}
+	size := ShareSize(len(secret))
+	for i, buf := range dst {
+		if len(buf) != size {
+			return fmt.Errorf("%w: destination buffer %d is %d bytes, want %d", ErrLengthMismatch, i, len(buf), size)
+		}
+	}
+	return splitFramedInto(rng, secret, t, n, v1Frame, nil, dst)
 }
 
 // Combine reconstructs the secret from exactly t shares.
+//
+// Shares may be passed in any order: they are sorted by index first, so the
+// result (and, when more than t shares are given, the subset used) does not
+// depend on the input order. The caller's slice is not modified.
+//
+// Shares of one split may mix format versions and integrity modes (e.g.
+// during a migration to SHA-256 tags): each share is verified against its
+// own tag, and only the fields that matter for reconstruction must agree.
+// Every share is checked as by ValidateShare before any field arithmetic.
 func Combine(shares [][]byte) ([]byte, error) {
-	t := len(shares)
-	if t < 2 {
-		return nil, errors.New("shamir: need at least 2 shares")
+	shares = sortByIndex(shares)
+	h, err := firstHeader(shares)
+	if err != nil {
+		return nil, err
+	}
+	return combine(shares, int(h[5]), true)
+}
+
+// CombineWithThreshold reconstructs the secret using the supplied threshold
+// instead of the one recorded in the share headers. It exists to recover
+// legacy shares whose threshold byte was corrupted; CRC, index and length
+// checks still apply.
+//
+// It bypasses a safety check: with a wrong threshold the result is a
+// plausible-looking but incorrect secret rather than an error. Only use it
+// when the real threshold is known out of band.
+func CombineWithThreshold(shares [][]byte, threshold int) ([]byte, error) {
+	if threshold < 2 || threshold > 255 {
+		return nil, fmt.Errorf("%w: threshold must be between 2 and 255", ErrInvalidParams)
+	}
+	shares = sortByIndex(shares)
+	if _, err := firstHeader(shares); err != nil {
+		return nil, err
+	}
+	return combine(shares, threshold, false)
+}
+
+// sortByIndex returns a copy of shares ordered by share index. Shares too
+// short to carry an index sort first so validation reports them.
+func sortByIndex(shares [][]byte) [][]byte {
+	sorted := make([][]byte, len(shares))
+	copy(sorted, shares)
+	key := func(s []byte) int {
+		if len(s) < headLen {
+			return -1
+		}
+		return int(s[9])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return key(sorted[i]) < key(sorted[j])
+	})
+	return sorted
+}
+
+// firstHeader checks the share count and the header of the first share.
+func firstHeader(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 shares", ErrInsufficientShares)
 	}
 	h := shares[0]
-	if len(h) < 10 {
-		return nil, errors.New("shamir: invalid share length")
+	if len(h) < headLen {
+		return nil, fmt.Errorf("%w: share too short", ErrLengthMismatch)
 	}
 	if string(h[0:4]) != magicHeader {
-		return nil, errors.New("shamir: bad magic header")
+		return nil, ErrBadMagic
 	}
-	if h[4] != version {
-		return nil, errors.New("shamir: version mismatch")
+	if h[4] != version && h[4] != versionV2 {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrVersionMismatch, h[4])
 	}
-	threshold := int(h[5])
-	total := h[6]
-	secretLen := int(binary.BigEndian.Uint16(h[7:9]))
+	return h, nil
+}
+
+// combine validates the first threshold shares and interpolates them at x=0.
+// When checkThreshold is false the header threshold byte is ignored.
+func combine(shares [][]byte, threshold int, checkThreshold bool) ([]byte, error) {
+	xs, data, err := collectShares(shares, threshold, checkThreshold)
+	if err != nil {
+		return nil, err
+	}
+	return unpackSecret(shares[0], interpolate(lagrange(xs), data))
+}
+
+// collectShares validates the first threshold shares against the first
+// share's header and returns their x-coordinates and payloads.
+func collectShares(shares [][]byte, threshold int, checkThreshold bool) ([]byte, [][]byte, error) {
+	return collectSharesAAD(shares, threshold, checkThreshold, nil)
+}
+
+// collectSharesAAD is collectShares for shares that may be bound to aad.
+func collectSharesAAD(shares [][]byte, threshold int, checkThreshold bool, aad []byte) ([]byte, [][]byte, error) {
+	if threshold < 2 {
+		// a corrupt header would otherwise "reconstruct" from 0 or 1 shares
+		return nil, nil, fmt.Errorf("%w: threshold %d", ErrInvalidParams, threshold)
+	}
+	t := len(shares)
 	if t < threshold {
-		return nil, errors.New("shamir: insufficient shares provided")
+		return nil, nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, t, threshold)
 	} else if t > threshold {
 		shares = shares[:threshold]
 		t = threshold
@@ -190,26 +337,34 @@ func Combine(shares [][]byte) ([]byte, error) {
 	xs := make([]byte, t)
 	data := make([][]byte, t)
 	seen := make(map[byte]bool, t)
+	var first shareInfo
 	for i, buf := range shares {
-		if len(buf) != headLen+secretLen+4 {
-			return nil, errors.New("shamir: share length mismatch")
+		info, err := parseShareAAD(buf, aad)
+		if err != nil {
+			return nil, nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if i == 0 {
+			first = info
 		}
-		end := len(buf)
-		expected := binary.BigEndian.Uint32(buf[end-4:])
-		if crc32.ChecksumIEEE(buf[:end-4]) != expected {
-			return nil, errors.New("shamir: CRC32 mismatch")
+		if !sameSplit(info, first) {
+			return nil, nil, splitMismatch(first, info)
 		}
-		if buf[5] != byte(threshold) || buf[6] != total {
-			return nil, errors.New("shamir: inconsistent header fields")
+		if checkThreshold && int(info.threshold) != threshold {
+			return nil, nil, fmt.Errorf("%w: share %d", ErrHeaderMismatch, info.index)
 		}
-		x := buf[9]
-		if x == 0 || seen[x] {
-			return nil, errors.New("shamir: invalid or duplicate index")
+		if seen[info.index] {
+			return nil, nil, fmt.Errorf("%w: %d", ErrDuplicateIndex, info.index)
 		}
-		seen[x] = true
-		xs[i] = x
-		data[i] = buf[headLen : headLen+secretLen]
+		seen[info.index] = true
+		xs[i] = info.index
+		data[i] = info.payload
 	}
+	return xs, data, nil
+}
+
+// lagrange computes the Lagrange basis weights at x=0 for distinct non-zero xs.
+func lagrange(xs []byte) []byte {
+	t := len(xs)
 	prodAll := byte(1)
 	for _, x := range xs {
 		prodAll = mul(prodAll, x)
@@ -227,27 +382,56 @@ func Combine(shares [][]byte) ([]byte, error) {
 		d1, _ := inv(d)
 		lags[i] = mul(mul(prodAll, i1), d1)
 	}
+	return lags
+}
+
+// interpolate combines share payloads with precomputed Lagrange weights.
+func interpolate(lags []byte, data [][]byte) []byte {
+	secretLen := 0
+	if len(data) > 0 {
+		secretLen = len(data[0])
+	}
 	secret := make([]byte, secretLen)
 	for j := 0; j < secretLen; j++ {
 		var v byte
-		for i := 0; i < t; i++ {
+		for i := range lags {
 			v ^= mul(data[i][j], lags[i])
 		}
 		secret[j] = v
 	}
-	return secret, nil
+	return secret
 }
 
 // StoreShares saves all shares to the given storage.
 func StoreShares(shares [][]byte, st IStorage) error {
 	batch := make(map[byte][]byte, len(shares))
-	for _, s := range shares {
-		batch[s[9]] = s
+	for i, s := range shares {
+		idx, err := ShareIndex(s)
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		batch[idx] = s
 	}
 	return st.BatchSet(batch)
 }
 
-// RetrieveShares fetches specific shares by indices.
+// listSharesSorted lists the indices held by st in ascending order, using
+// the backend's ListSharesSorted when it provides one.
+func listSharesSorted(st IStorage) ([]byte, error) {
+	if sl, ok := st.(interface{ ListSharesSorted() ([]byte, error) }); ok {
+		return sl.ListSharesSorted()
+	}
+	indices, err := st.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
+}
+
+// RetrieveShares fetches specific shares by indices, in the order given.
+// Indices obtained from ListShares are not guaranteed to be sorted; use a
+// backend's ListSharesSorted (or sort them) when a stable subset matters.
 func RetrieveShares(indices []byte, st IStorage) ([][]byte, error) {
 	var out [][]byte
 	for _, idx := range indices {
@@ -267,14 +451,60 @@ func MultiPartyAuthorize(st IStorage, indices []byte, threshold int) ([]byte, er
 		return nil, err
 	}
 	if len(shs) < threshold {
-		return nil, errors.New("shamir: insufficient shares for threshold")
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(shs), threshold)
 	}
 	return Combine(shs[:threshold])
 }
 
-// BreakGlassRecovery uses a separate set of recovery shares.
-func BreakGlassRecovery(st IStorage, indices []byte, threshold int) ([]byte, error) {
-	return MultiPartyAuthorize(st, indices, threshold)
+// CombineFromStorage reconstructs the secret from any threshold valid shares
+// in st. Indices are tried in ascending order; shares that can't be read,
+// fail their integrity check, or don't belong with the first valid share
+// are skipped, as are indices the store reports as revoked through a
+// Revoked(index byte) bool method. The error wraps ErrInsufficientShares,
+// joined with the reasons shares were skipped, when fewer than threshold
+// valid shares exist.
+func CombineFromStorage(st IStorage, threshold int) ([]byte, error) {
+	if threshold < 2 || threshold > 255 {
+		return nil, fmt.Errorf("%w: threshold must be between 2 and 255", ErrInvalidParams)
+	}
+	indices, err := listSharesSorted(st)
+	if err != nil {
+		return nil, err
+	}
+	revoked, _ := st.(interface{ Revoked(index byte) bool })
+	set := NewShareSet()
+	var skipped []error
+	for _, idx := range indices {
+		if set.Len() == threshold {
+			break
+		}
+		if revoked != nil && revoked.Revoked(idx) {
+			continue
+		}
+		s, err := st.GetShare(idx)
+		if err == nil {
+			err = addWithThreshold(set, s, threshold)
+		}
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("share %d: %w", idx, err))
+		}
+	}
+	if set.Len() < threshold {
+		return nil, fmt.Errorf("%w: %d valid of %d needed: %w", ErrInsufficientShares, set.Len(), threshold, errors.Join(skipped...))
+	}
+	return set.Combine()
+}
+
+// addWithThreshold adds share to set if its header threshold is threshold.
+func addWithThreshold(set *ShareSet, share []byte, threshold int) error {
+	t, err := ShareThreshold(share)
+	if err != nil {
+		return err
+	}
+	if int(t) != threshold {
+		return fmt.Errorf("%w: threshold %d, expected %d", ErrHeaderMismatch, t, threshold)
+	}
+	return set.Add(share)
 }
 
 // EncodeBase64 returns a base64 string of a raw share.
@@ -299,18 +529,9 @@ func DecodeHex(s string) ([]byte, error) {
 
 // ToJSON converts a share into JSON form.
 func ToJSON(share []byte) (string, error) {
-	if len(share) < 10 {
-		return "", errors.New("shamir: invalid share")
-	}
-	thr := share[5]
-	tot := share[6]
-	idx := share[9]
-	body := share[9 : len(share)-4]
-	j := ShareJSON{
-		Index:       idx,
-		Threshold:   thr,
-		TotalShares: tot,
-		Data:        base64.StdEncoding.EncodeToString(body),
+	j, err := ShareToStruct(share)
+	if err != nil {
+		return "", err
 	}
 	b, err := json.Marshal(j)
 	return string(b), err
@@ -322,20 +543,77 @@ func FromJSON(js string) ([]byte, error) {
 	if err := json.Unmarshal([]byte(js), &j); err != nil {
 		return nil, err
 	}
+	return ShareFromStruct(j)
+}
+
+// ShareToStruct converts a share into its portable envelope without
+// marshalling it, for callers that encode the struct themselves.
+func ShareToStruct(share []byte) (ShareJSON, error) {
+	info, err := parseFrame(share)
+	if err != nil {
+		return ShareJSON{}, err
+	}
+	j := ShareJSON{
+		Index:       info.index,
+		Threshold:   info.threshold,
+		TotalShares: info.total,
+		Data:        base64.StdEncoding.EncodeToString(info.payload),
+	}
+	if info.version != version {
+		j.Version = info.version
+		j.Flags = info.flags
+		if len(info.ext) > 0 {
+			j.Ext = base64.StdEncoding.EncodeToString(info.ext)
+		}
+		if info.integrity() == integrityHMAC {
+			// keyed tags can't be recomputed by ShareFromStruct
+			j.Tag = base64.StdEncoding.EncodeToString(info.tag)
+		}
+	}
+	return j, nil
+}
+
+// ShareFromStruct rebuilds a raw share from its portable envelope, for
+// callers that already hold a decoded ShareJSON (e.g. from a request body).
+func ShareFromStruct(j ShareJSON) ([]byte, error) {
+	if n := base64.StdEncoding.DecodedLen(len(j.Data)); n > MaxSecretLen+2 {
+		return nil, fmt.Errorf("%w: payload of about %d bytes", ErrSecretTooLarge, n)
+	}
 	data, err := base64.StdEncoding.DecodeString(j.Data)
 	if err != nil {
 		return nil, err
 	}
-	secretLen := len(data)
-	buf := make([]byte, headLen+secretLen+4)
-	copy(buf[0:], []byte(magicHeader))
-	buf[4] = version
-	buf[5] = j.Threshold
-	buf[6] = j.TotalShares
-	binary.BigEndian.PutUint16(buf[7:], uint16(secretLen))
-	buf[9] = j.Index
-	copy(buf[10:], data)
-	crc := crc32.ChecksumIEEE(buf[:len(buf)-4])
-	binary.BigEndian.PutUint32(buf[len(buf)-4:], crc)
+	if len(data) > MaxSecretLen {
+		return nil, fmt.Errorf("%w: payload of %d bytes", ErrSecretTooLarge, len(data))
+	}
+	if len(data) > 0xFFFF {
+		return nil, fmt.Errorf("%w: payload longer than 65535 bytes", ErrLengthMismatch)
+	}
+	f := v1Frame
+	switch j.Version {
+	case 0, version:
+	case versionV2:
+		ext, err := base64.StdEncoding.DecodeString(j.Ext)
+		if err != nil {
+			return nil, err
+		}
+		if !validFlags(j.Flags) || len(ext) != extLen(j.Flags) {
+			return nil, fmt.Errorf("%w: bad flags or extension fields", ErrHeaderMismatch)
+		}
+		f = frame{version: versionV2, flags: j.Flags, ext: ext}
+	default:
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrVersionMismatch, j.Version)
+	}
+	buf := newShare(f, j.Threshold, j.TotalShares, j.Index, data)
+	if f.integrity() == integrityHMAC {
+		tag, err := base64.StdEncoding.DecodeString(j.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if len(tag) != f.tagLen() {
+			return nil, fmt.Errorf("%w: bad tag length %d", ErrLengthMismatch, len(tag))
+		}
+		copy(buf[len(buf)-len(tag):], tag)
+	}
 	return buf, nil
 }
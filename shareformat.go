@@ -0,0 +1,137 @@
+// shareformat.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownFormat is returned when a blob isn't a valid share in any
+// supported encoding.
+var ErrUnknownFormat = errors.New("shamir: not a share in any known format")
+
+// ShareFormat identifies an encoding of a share.
+type ShareFormat int
+
+const (
+	FormatUnknown  ShareFormat = iota
+	FormatBinary               // raw share bytes
+	FormatBase64               // EncodeBase64
+	FormatHex                  // EncodeHex
+	FormatJSON                 // ToJSON
+	FormatMnemonic             // EncodeMnemonic, words separated by spaces
+)
+
+// String returns the format's name.
+func (f ShareFormat) String() string {
+	switch f {
+	case FormatBinary:
+		return "binary"
+	case FormatBase64:
+		return "base64"
+	case FormatHex:
+		return "hex"
+	case FormatJSON:
+		return "json"
+	case FormatMnemonic:
+		return "mnemonic"
+	}
+	return "unknown"
+}
+
+// detectOrder is the order in which DetectFormat tries the formats. Hex is
+// tried before base64 because every hex string is also valid base64.
+var detectOrder = []ShareFormat{FormatBinary, FormatJSON, FormatHex, FormatBase64, FormatMnemonic}
+
+// DetectFormat reports the encoding of a share. A format matches only if
+// the blob decodes to a share that passes ValidateShare, and the formats are
+// tried in a fixed order (binary, JSON, hex, base64, mnemonic), so the result
+// is deterministic. Surrounding whitespace is ignored for the text formats.
+// Shares bound to AAD are detected in every format, so anything ConvertShare
+// produces can be detected and converted back. A blob that is no valid share
+// fails with ErrUnknownFormat.
+func DetectFormat(data []byte) (ShareFormat, error) {
+	for _, f := range detectOrder {
+		share, err := decodeFormat(data, f)
+		if err != nil {
+			continue
+		}
+		if f != FormatBinary {
+			wipe(share)
+		}
+		return f, nil
+	}
+	return FormatUnknown, ErrUnknownFormat
+}
+
+// ConvertShare decodes input from one format and re-encodes it in another.
+// from may be FormatUnknown to detect it. The share is validated on the way
+// through, so a corrupt share fails rather than being converted.
+func ConvertShare(input []byte, from, to ShareFormat) ([]byte, error) {
+	if from == FormatUnknown {
+		var err error
+		if from, err = DetectFormat(input); err != nil {
+			return nil, err
+		}
+	}
+	share, err := decodeFormat(input, from)
+	if err != nil {
+		return nil, err
+	}
+	if from != FormatBinary {
+		defer wipe(share)
+	}
+	return encodeFormat(share, to)
+}
+
+// decodeFormat decodes data as format f and validates the result.
+func decodeFormat(data []byte, f ShareFormat) ([]byte, error) {
+	text := string(bytes.TrimSpace(data))
+	var share []byte
+	var err error
+	switch f {
+	case FormatBinary:
+		share = data
+	case FormatBase64:
+		share, err = DecodeBase64(text)
+	case FormatHex:
+		share, err = DecodeHex(text)
+	case FormatJSON:
+		share, err = FromJSON(text)
+	case FormatMnemonic:
+		share, err = DecodeMnemonic(strings.Fields(text))
+	default:
+		return nil, fmt.Errorf("%w: share format %d", ErrInvalidParams, f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("shamir: decode %s share: %w", f, err)
+	}
+	if err := ValidateShare(share); err != nil {
+		if f != FormatBinary {
+			wipe(share)
+		}
+		return nil, fmt.Errorf("shamir: decode %s share: %w", f, err)
+	}
+	return share, nil
+}
+
+// encodeFormat encodes a raw share as format f.
+func encodeFormat(share []byte, f ShareFormat) ([]byte, error) {
+	switch f {
+	case FormatBinary:
+		return append([]byte(nil), share...), nil
+	case FormatBase64:
+		return []byte(EncodeBase64(share)), nil
+	case FormatHex:
+		return []byte(EncodeHex(share)), nil
+	case FormatJSON:
+		js, err := ToJSON(share)
+		return []byte(js), err
+	case FormatMnemonic:
+		words, err := EncodeMnemonic(share)
+		return []byte(strings.Join(words, " ")), err
+	}
+	return nil, fmt.Errorf("%w: share format %d", ErrInvalidParams, f)
+}
@@ -0,0 +1,57 @@
+// shareformat_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestConvertShareRoundTrip(t *testing.T) {
+	formats := []ShareFormat{FormatBinary, FormatBase64, FormatHex, FormatJSON, FormatMnemonic}
+	tests := []struct {
+		name string
+		opts SplitOptions
+	}{
+		{"v1", SplitOptions{}},
+		{"digest", SplitOptions{EmbedDigest: true}},
+		{"aad", SplitOptions{AAD: []byte("tenant")}},
+	}
+	for _, tt := range tests {
+		shares, err := SplitWithOptions([]byte("format secret"), 2, 3, tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		share := shares[0]
+		for _, to := range formats {
+			t.Run(tt.name+"/"+to.String(), func(t *testing.T) {
+				enc, err := ConvertShare(share, FormatBinary, to)
+				if err != nil {
+					t.Fatalf("ConvertShare to %s: %v", to, err)
+				}
+				got, err := DetectFormat(enc)
+				if err != nil {
+					t.Fatalf("DetectFormat: %v", err)
+				}
+				if got != to {
+					t.Fatalf("DetectFormat = %s, want %s", got, to)
+				}
+				back, err := ConvertShare(enc, FormatUnknown, FormatBinary)
+				if err != nil {
+					t.Fatalf("ConvertShare back: %v", err)
+				}
+				if !bytes.Equal(back, share) {
+					t.Fatal("round trip changed the share")
+				}
+			})
+		}
+	}
+}
+
+func TestDetectFormatUnknown(t *testing.T) {
+	for _, in := range []string{"", "not a share", "SHAM", "00ff"} {
+		if _, err := DetectFormat([]byte(in)); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("DetectFormat(%q) = %v, want ErrUnknownFormat", in, err)
+		}
+	}
+}
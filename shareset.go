@@ -0,0 +1,102 @@
+// shareset.go
+package shamir
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// ShareSet accumulates shares of one split, rejecting any share whose header
+// disagrees with the shares already added. It is not safe for concurrent use.
+type ShareSet struct {
+	first  shareInfo
+	shares map[byte][]byte
+}
+
+// NewShareSet returns an empty set. Its threshold and total are fixed by the
+// first share added.
+func NewShareSet() *ShareSet {
+	return &ShareSet{shares: make(map[byte][]byte)}
+}
+
+// Add verifies a share and adds a copy of it to the set. A share from a
+// different split (threshold, total, length or flags differ) fails
+// with ErrHeaderMismatch. Re-adding an identical share fails with
+// ErrDuplicateIndex and a different share for a held index with
+// ErrConflictingIndex; the set is unchanged in every error case.
+func (ss *ShareSet) Add(share []byte) error {
+	info, err := parseShare(share)
+	if err != nil {
+		return err
+	}
+	if len(ss.shares) > 0 {
+		f := ss.first
+		if !sameSplit(info, f) {
+			return splitMismatch(f, info)
+		}
+		if info.threshold != f.threshold {
+			return fmt.Errorf("%w: share %d does not belong to this set", ErrHeaderMismatch, info.index)
+		}
+	}
+	if prev, ok := ss.shares[info.index]; ok {
+		if bytes.Equal(prev, share) {
+			return fmt.Errorf("%w: %d", ErrDuplicateIndex, info.index)
+		}
+		return fmt.Errorf("%w: %d", ErrConflictingIndex, info.index)
+	}
+	c := make([]byte, len(share))
+	copy(c, share)
+	if len(ss.shares) == 0 {
+		// keep the parsed view pointing at our copy
+		ss.first, _ = parseFrame(c)
+	}
+	ss.shares[info.index] = c
+	return nil
+}
+
+// Len returns the number of shares held.
+func (ss *ShareSet) Len() int { return len(ss.shares) }
+
+// Threshold returns the threshold of the set, or 0 while it is empty.
+func (ss *ShareSet) Threshold() int {
+	if len(ss.shares) == 0 {
+		return 0
+	}
+	return int(ss.first.threshold)
+}
+
+// Total returns the total share count of the set, or 0 while it is empty.
+func (ss *ShareSet) Total() int {
+	if len(ss.shares) == 0 {
+		return 0
+	}
+	return int(ss.first.total)
+}
+
+// Indices returns the held share indices in ascending order.
+func (ss *ShareSet) Indices() []byte {
+	idx := make([]byte, 0, len(ss.shares))
+	for i := range ss.shares {
+		idx = append(idx, i)
+	}
+	sort.Slice(idx, func(i, j int) bool { return idx[i] < idx[j] })
+	return idx
+}
+
+// QuorumReached reports whether enough shares are held to combine.
+func (ss *ShareSet) QuorumReached() bool {
+	return len(ss.shares) > 0 && len(ss.shares) >= int(ss.first.threshold)
+}
+
+// Combine reconstructs the secret from the held shares.
+func (ss *ShareSet) Combine() ([]byte, error) {
+	if !ss.QuorumReached() {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientShares, len(ss.shares), ss.Threshold())
+	}
+	shares := make([][]byte, 0, len(ss.shares))
+	for _, i := range ss.Indices() {
+		shares = append(shares, ss.shares[i])
+	}
+	return Combine(shares)
+}
@@ -0,0 +1,146 @@
+// sheet.go
+package shamir
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// recoverySheet is the data rendered into a recovery sheet.
+type recoverySheet struct {
+	Custodian   string
+	Index       byte
+	Threshold   byte
+	Total       byte
+	Fingerprint string
+	Words       [][]string // mnemonic in numbered rows
+	FirstWord   []int      // number of the first word of each row
+	Base64      string
+	SchemeID    uint32
+	CreatedAt   string
+}
+
+const sheetWordsPerRow = 6
+
+const sheetText = `SHAMIR SECRET SHARE - RECOVERY SHEET
+====================================
+
+Custodian:    {{.Custodian}}
+Share:        {{.Index}} of {{.Total}} ({{.Threshold}} needed to recover)
+{{- if .CreatedAt}}
+Created:      {{.CreatedAt}}
+Scheme ID:    {{.SchemeID}}
+{{- end}}
+Fingerprint:  {{.Fingerprint}}
+
+Recovery words:
+{{range $i, $row := .Words}}  {{index $.FirstWord $i | printf "%3d"}}. {{join $row " "}}
+{{end}}
+Base64:
+  {{.Base64}}
+
+To recover the secret:
+  1. Bring this sheet together with the sheets of at least {{.Threshold}} custodians
+     in total.
+  2. Enter each share's recovery words (or its base64 line) into the recovery
+     tool. Words are not case-sensitive; a checksum rejects typos.
+  3. Check that the SHA-256 fingerprint the tool reports matches the one above.
+
+Keep this sheet secret. On its own it reveals nothing about the secret, but
+{{.Threshold}} sheets together reveal it completely.
+`
+
+const sheetHTML = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Recovery sheet - share {{.Index}}</title></head>
+<body>
+<h1>Shamir secret share &ndash; recovery sheet</h1>
+<table>
+<tr><th>Custodian</th><td>{{.Custodian}}</td></tr>
+<tr><th>Share</th><td>{{.Index}} of {{.Total}} ({{.Threshold}} needed to recover)</td></tr>
+{{- if .CreatedAt}}
+<tr><th>Created</th><td>{{.CreatedAt}}</td></tr>
+<tr><th>Scheme ID</th><td>{{.SchemeID}}</td></tr>
+{{- end}}
+<tr><th>Fingerprint</th><td><code>{{.Fingerprint}}</code></td></tr>
+</table>
+<h2>Recovery words</h2>
+<ol>{{range .Words}}{{range .}}<li>{{.}}</li>{{end}}{{end}}</ol>
+<h2>Base64</h2>
+<p><code style="word-break: break-all">{{.Base64}}</code></p>
+<h2>To recover the secret</h2>
+<ol>
+<li>Bring this sheet together with the sheets of at least {{.Threshold}} custodians in total.</li>
+<li>Enter each share's recovery words (or its base64 line) into the recovery tool. Words are not case-sensitive; a checksum rejects typos.</li>
+<li>Check that the SHA-256 fingerprint the tool reports matches the one above.</li>
+</ol>
+<p>Keep this sheet secret. On its own it reveals nothing about the secret, but {{.Threshold}} sheets together reveal it completely.</p>
+</body></html>
+`
+
+var (
+	sheetTextTmpl = template.Must(template.New("sheet").Funcs(template.FuncMap{"join": strings.Join}).Parse(sheetText))
+	sheetHTMLTmpl = htmltemplate.Must(htmltemplate.New("sheet").Parse(sheetHTML))
+)
+
+// RenderRecoverySheet renders a plaintext recovery sheet for a custodian:
+// the share's index and scheme, its fingerprint (see Fingerprint), the share
+// as mnemonic words (see EncodeMnemonic) and as base64, and reconstruction
+// instructions. The sheet contains the share itself and must be handled as
+// secret.
+func RenderRecoverySheet(share []byte, custodian string) (string, error) {
+	data, err := newRecoverySheet(share, custodian)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := sheetTextTmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderRecoverySheetHTML is RenderRecoverySheet producing a standalone HTML
+// page for printing.
+func RenderRecoverySheetHTML(share []byte, custodian string) (string, error) {
+	data, err := newRecoverySheet(share, custodian)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := sheetHTMLTmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func newRecoverySheet(share []byte, custodian string) (recoverySheet, error) {
+	var r recoverySheet
+	info, err := parseShare(share)
+	if err != nil {
+		return r, err
+	}
+	words, err := EncodeMnemonic(share)
+	if err != nil {
+		return r, err
+	}
+	r.Custodian = custodian
+	r.Index = info.index
+	r.Threshold = info.threshold
+	r.Total = info.total
+	r.Fingerprint = Fingerprint(share)
+	r.Base64 = EncodeBase64(share)
+	for i := 0; i < len(words); i += sheetWordsPerRow {
+		end := min(i+sheetWordsPerRow, len(words))
+		r.Words = append(r.Words, words[i:end])
+		r.FirstWord = append(r.FirstWord, i+1)
+	}
+	if m := info.meta(); m != nil {
+		created, id := decodeMeta(m)
+		r.CreatedAt = created.Format(time.RFC3339)
+		r.SchemeID = id
+	}
+	return r, nil
+}
@@ -0,0 +1,54 @@
+// source.go
+package shamir
+
+import "fmt"
+
+// ShareSource is anything that can yield a raw binary share.
+type ShareSource interface {
+	ShareBytes() ([]byte, error)
+}
+
+// RawShare is a share already in raw binary form.
+type RawShare []byte
+
+// ShareBytes returns the share unchanged.
+func (r RawShare) ShareBytes() ([]byte, error) {
+	return []byte(r), nil
+}
+
+// Base64Share is a share encoded with EncodeBase64.
+type Base64Share string
+
+// ShareBytes decodes the base64 share.
+func (b Base64Share) ShareBytes() ([]byte, error) {
+	return DecodeBase64(string(b))
+}
+
+// HexShare is a share encoded with EncodeHex.
+type HexShare string
+
+// ShareBytes decodes the hex share.
+func (h HexShare) ShareBytes() ([]byte, error) {
+	return DecodeHex(string(h))
+}
+
+// JSONShare is a share encoded with ToJSON.
+type JSONShare string
+
+// ShareBytes parses the JSON share.
+func (j JSONShare) ShareBytes() ([]byte, error) {
+	return FromJSON(string(j))
+}
+
+// CombineFrom decodes each source and combines the resulting shares.
+func CombineFrom(sources ...ShareSource) ([]byte, error) {
+	shares := make([][]byte, 0, len(sources))
+	for i, src := range sources {
+		s, err := src.ShareBytes()
+		if err != nil {
+			return nil, fmt.Errorf("shamir: decode source %d: %w", i, err)
+		}
+		shares = append(shares, s)
+	}
+	return Combine(shares)
+}
@@ -0,0 +1,111 @@
+// splitguard.go
+package shamir
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+)
+
+// ErrCoefficientReuse is returned by a SplitGuard when a split drew the same
+// random coefficients as an earlier one.
+var ErrCoefficientReuse = errors.New("shamir: random coefficients reused across splits")
+
+const (
+	// DefaultSplitGuardSize is the number of splits a SplitGuard remembers
+	// when created with a non-positive size.
+	DefaultSplitGuardSize = 4096
+	// minGuardedRandom is the least randomness a split must draw to be
+	// recorded; shorter draws collide by chance too often to mean anything.
+	minGuardedRandom = 16
+)
+
+// SplitGuard catches catastrophic RNG failure, such as a deterministic or
+// stuck reader, by remembering a SHA-256 hash of the random coefficients of
+// each split and failing any later split that draws the same ones. Only the
+// coefficients are hashed, never the secret. It remembers the most recent
+// size splits, so memory is bounded at about 32 bytes per split. Splits
+// that draw fewer than 16 random bytes (threshold 1, or very short secrets)
+// are not checked. A SplitGuard is opt-in and safe for concurrent use.
+type SplitGuard struct {
+	mu   sync.Mutex
+	seen map[[sha256.Size]byte]struct{}
+	ring [][sha256.Size]byte
+	next int
+}
+
+// NewSplitGuard returns a guard remembering the last size splits.
+func NewSplitGuard(size int) *SplitGuard {
+	if size <= 0 {
+		size = DefaultSplitGuardSize
+	}
+	return &SplitGuard{
+		seen: make(map[[sha256.Size]byte]struct{}, size),
+		ring: make([][sha256.Size]byte, 0, size),
+	}
+}
+
+// Split is Split checked by the guard.
+func (g *SplitGuard) Split(secret []byte, t, n int) ([][]byte, error) {
+	return g.SplitWithReader(rand.Reader, secret, t, n)
+}
+
+// SplitWithReader is SplitWithReader checked by the guard. If the split
+// drew coefficients seen before, its shares are wiped and it fails with
+// ErrCoefficientReuse.
+func (g *SplitGuard) SplitWithReader(rng io.Reader, secret []byte, t, n int) ([][]byte, error) {
+	hr := &hashingReader{r: rng, h: sha256.New()}
+	shares, err := SplitWithReader(hr, secret, t, n)
+	if err != nil {
+		return nil, err
+	}
+	if hr.n < minGuardedRandom {
+		return shares, nil
+	}
+	var sum [sha256.Size]byte
+	hr.h.Sum(sum[:0])
+	if !g.record(sum) {
+		for _, s := range shares {
+			wipe(s)
+		}
+		return nil, fmt.Errorf("%w: the random source is repeating itself", ErrCoefficientReuse)
+	}
+	return shares, nil
+}
+
+// record adds sum, evicting the oldest entry when full, and reports whether
+// it was new.
+func (g *SplitGuard) record(sum [sha256.Size]byte) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.seen[sum]; ok {
+		return false
+	}
+	if len(g.ring) < cap(g.ring) {
+		g.ring = append(g.ring, sum)
+	} else {
+		delete(g.seen, g.ring[g.next])
+		g.ring[g.next] = sum
+		g.next = (g.next + 1) % len(g.ring)
+	}
+	g.seen[sum] = struct{}{}
+	return true
+}
+
+// hashingReader hashes and counts everything read through it.
+type hashingReader struct {
+	r io.Reader
+	h hash.Hash
+	n int
+}
+
+func (hr *hashingReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n])
+	hr.n += n
+	return n, err
+}
@@ -0,0 +1,116 @@
+// splitinto_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func seededReader() *rand.ChaCha8 {
+	return rand.NewChaCha8([32]byte{1, 2, 3})
+}
+
+func newShareBuffers(n, size int) [][]byte {
+	dst := make([][]byte, n)
+	for i := range dst {
+		dst[i] = bytes.Repeat([]byte{0xee}, size) // stale contents must be overwritten
+	}
+	return dst
+}
+
+func TestSplitIntoMatchesSplitWithReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret []byte
+		t, n   int
+	}{
+		{"2 of 3", []byte("into"), 2, 3},
+		{"5 of 9", bytes.Repeat([]byte("abc"), 40), 5, 9},
+		{"empty secret", []byte{}, 2, 2},
+		{"255 shares", []byte("wide"), 3, 255},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := SplitWithReader(seededReader(), tt.secret, tt.t, tt.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst := newShareBuffers(tt.n, ShareSize(len(tt.secret)))
+			if err := SplitInto(seededReader(), tt.secret, tt.t, tt.n, dst); err != nil {
+				t.Fatal(err)
+			}
+			for i := range want {
+				if !bytes.Equal(dst[i], want[i]) {
+					t.Fatalf("share %d = %x, want %x", i+1, dst[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitIntoRejectsBuffers(t *testing.T) {
+	secret := []byte("sized")
+	size := ShareSize(len(secret))
+	short := newShareBuffers(3, size)
+	short[1] = short[1][:size-1]
+	long := newShareBuffers(3, size)
+	long[2] = append(long[2], 0)
+	tests := []struct {
+		name    string
+		dst     [][]byte
+		wantErr error
+	}{
+		{"too few buffers", newShareBuffers(2, size), ErrInvalidParams},
+		{"too many buffers", newShareBuffers(4, size), ErrInvalidParams},
+		{"short buffer", short, ErrLengthMismatch},
+		{"long buffer", long, ErrLengthMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SplitInto(seededReader(), secret, 2, 3, tt.dst); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SplitInto error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func BenchmarkSplitInto(b *testing.B) {
+	secret := bytes.Repeat([]byte{0x42}, 32)
+	rng := seededReader()
+	b.Run("SplitInto", func(b *testing.B) {
+		dst := newShareBuffers(5, ShareSize(len(secret)))
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := SplitInto(rng, secret, 3, 5, dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("SplitWithReader", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := SplitWithReader(rng, secret, 3, 5); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestSplitIntoDoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	secret := bytes.Repeat([]byte{0x42}, 32)
+	dst := newShareBuffers(5, ShareSize(len(secret)))
+	rng := seededReader()
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := SplitInto(rng, secret, 3, 5, dst); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("SplitInto allocates %v times per call", allocs)
+	}
+}
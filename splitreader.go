@@ -0,0 +1,52 @@
+// splitreader.go
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// secretPool holds scratch buffers large enough for any splittable secret.
+// Buffers are always wiped before being returned to the pool.
+var secretPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0xFFFF+1)
+		return &buf
+	},
+}
+
+// SplitReader reads a secret of at most maxLen bytes from src and splits it
+// into n shares requiring t to reconstruct. The secret is only ever held in
+// a pooled scratch buffer that is zeroed before SplitReader returns, on
+// success and on every error path, so the caller's reader is the only
+// long-lived copy. maxLen must be between 1 and 65535; a longer secret fails
+// with ErrInvalidParams. rng may be nil to use crypto/rand.
+func SplitReader(rng io.Reader, src io.Reader, t, n int, maxLen int) ([][]byte, error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, err
+	}
+	if maxLen < 1 || maxLen > 0xFFFF {
+		return nil, fmt.Errorf("%w: maxLen must be between 1 and 65535", ErrInvalidParams)
+	}
+	if rng == nil {
+		rng = rand.Reader
+	}
+	pb := secretPool.Get().(*[]byte)
+	buf := (*pb)[:maxLen+1]
+	defer func() {
+		wipe(buf)
+		secretPool.Put(pb)
+	}()
+	m, err := io.ReadFull(src, buf)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("%w: secret longer than %d bytes", ErrInvalidParams, maxLen)
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+	default:
+		return nil, fmt.Errorf("shamir: read secret: %w", err)
+	}
+	return split(rng, buf[:m], t, n)
+}
@@ -0,0 +1,88 @@
+// storage/acl.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPermissionDenied is returned by ACLStorage for an operation the caller
+// isn't authorized for.
+var ErrPermissionDenied = errors.New("shamir: permission denied")
+
+// Operations passed to an ACLStorage predicate.
+const (
+	OpGet    = "get"
+	OpSet    = "set"
+	OpDelete = "delete"
+	OpList   = "list"
+)
+
+var _ IStorage = (*ACLStorage)(nil)
+
+// ACLStorage enforces per-index access control in front of a storage, for
+// multi-tenant custody where each caller may only touch its own shares.
+type ACLStorage struct {
+	inner      IStorage
+	authorized func(op string, index byte) bool
+}
+
+// WithACL wraps inner so every operation on an index is first checked with
+// authorized, called with one of OpGet, OpSet, OpDelete or OpList. Denied
+// operations fail with ErrPermissionDenied without reaching inner;
+// ListShares silently omits indices not authorized for OpList.
+func WithACL(inner IStorage, authorized func(op string, index byte) bool) IStorage {
+	return &ACLStorage{inner: inner, authorized: authorized}
+}
+
+func (as *ACLStorage) check(op string, index byte) error {
+	if !as.authorized(op, index) {
+		return fmt.Errorf("%w: %s share %d", ErrPermissionDenied, op, index)
+	}
+	return nil
+}
+
+func (as *ACLStorage) SetShare(index byte, share []byte) error {
+	if err := as.check(OpSet, index); err != nil {
+		return err
+	}
+	return as.inner.SetShare(index, share)
+}
+
+func (as *ACLStorage) GetShare(index byte) ([]byte, error) {
+	if err := as.check(OpGet, index); err != nil {
+		return nil, err
+	}
+	return as.inner.GetShare(index)
+}
+
+func (as *ACLStorage) ListShares() ([]byte, error) {
+	indices, err := as.inner.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	kept := indices[:0]
+	for _, idx := range indices {
+		if as.authorized(OpList, idx) {
+			kept = append(kept, idx)
+		}
+	}
+	return kept, nil
+}
+
+func (as *ACLStorage) DeleteShare(index byte) error {
+	if err := as.check(OpDelete, index); err != nil {
+		return err
+	}
+	return as.inner.DeleteShare(index)
+}
+
+// BatchSet refuses the whole batch if any index is not authorized.
+func (as *ACLStorage) BatchSet(shares map[byte][]byte) error {
+	for _, idx := range SortedIndices(shares) {
+		if err := as.check(OpSet, idx); err != nil {
+			return err
+		}
+	}
+	return as.inner.BatchSet(shares)
+}
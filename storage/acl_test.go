@@ -0,0 +1,58 @@
+// storage/acl_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestACLStorage(t *testing.T) {
+	inner := drivers.NewMemoryStorage()
+	if err := inner.BatchSet(map[byte][]byte{1: []byte("one"), 2: []byte("two")}); err != nil {
+		t.Fatal(err)
+	}
+	st := storage.WithACL(inner, func(op string, index byte) bool { return index == 1 })
+
+	denied := []struct {
+		name string
+		run  func() error
+	}{
+		{"get", func() error { _, err := st.GetShare(2); return err }},
+		{"set", func() error { return st.SetShare(2, []byte("overwritten")) }},
+		{"delete", func() error { return st.DeleteShare(2) }},
+		{"batch set", func() error {
+			return st.BatchSet(map[byte][]byte{1: []byte("overwritten"), 2: []byte("overwritten")})
+		}},
+	}
+	for _, tt := range denied {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, storage.ErrPermissionDenied) {
+				t.Fatalf("error = %v, want ErrPermissionDenied", err)
+			}
+			for idx, want := range map[byte]string{1: "one", 2: "two"} {
+				got, err := inner.GetShare(idx)
+				if err != nil || string(got) != want {
+					t.Fatalf("inner share %d = %q, %v, want %q untouched", idx, got, err, want)
+				}
+			}
+		})
+	}
+
+	indices, err := st.ListShares()
+	if err != nil || !bytes.Equal(indices, []byte{1}) {
+		t.Fatalf("ListShares = %v, %v, want [1]", indices, err)
+	}
+	if got, err := st.GetShare(1); err != nil || string(got) != "one" {
+		t.Fatalf("GetShare(1) = %q, %v", got, err)
+	}
+	if err := st.SetShare(1, []byte("uno")); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.DeleteShare(1); err != nil {
+		t.Fatal(err)
+	}
+}
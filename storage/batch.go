@@ -0,0 +1,44 @@
+// storage/batch.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// PartialBatchSetter is implemented by storages that can apply a batch past
+// individual failures instead of aborting on the first one.
+type PartialBatchSetter interface {
+	BatchSetPartial(shares map[byte][]byte) (succeeded []byte, err error)
+}
+
+// BatchSetPartial attempts to store every share in the batch, returning the
+// indices that were written and the per-index failures joined into one error.
+// It uses st's own implementation when available and falls back to SetShare.
+func BatchSetPartial(st IStorage, shares map[byte][]byte) ([]byte, error) {
+	if p, ok := st.(PartialBatchSetter); ok {
+		return p.BatchSetPartial(shares)
+	}
+	var succeeded []byte
+	var errs []error
+	for _, idx := range SortedIndices(shares) {
+		if err := st.SetShare(idx, shares[idx]); err != nil {
+			errs = append(errs, fmt.Errorf("shamir: share %d: %w", idx, err))
+			continue
+		}
+		succeeded = append(succeeded, idx)
+	}
+	return succeeded, errors.Join(errs...)
+}
+
+// SortedIndices returns the indices of a batch in ascending order, for
+// storages that apply a batch one share at a time.
+func SortedIndices(shares map[byte][]byte) []byte {
+	keys := make([]byte, 0, len(shares))
+	for idx := range shares {
+		keys = append(keys, idx)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
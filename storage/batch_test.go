@@ -0,0 +1,157 @@
+// storage/batch_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestSortedIndices(t *testing.T) {
+	got := storage.SortedIndices(map[byte][]byte{9: nil, 1: nil, 255: nil, 4: nil})
+	if !bytes.Equal(got, []byte{1, 4, 9, 255}) {
+		t.Fatalf("SortedIndices = %v", got)
+	}
+	if got := storage.SortedIndices(nil); len(got) != 0 {
+		t.Fatalf("SortedIndices(nil) = %v", got)
+	}
+}
+
+func TestPageIndices(t *testing.T) {
+	sorted := []byte{1, 2, 3, 4, 5}
+	tests := []struct {
+		cursor, limit int
+		want          []byte
+		next          int
+		wantErr       bool
+	}{
+		{0, 2, []byte{1, 2}, 2, false},
+		{2, 2, []byte{3, 4}, 4, false},
+		{4, 2, []byte{5}, 0, false},
+		{0, 5, []byte{1, 2, 3, 4, 5}, 0, false},
+		{7, 2, []byte{}, 0, false},
+		{-1, 2, nil, 0, true},
+		{0, 0, nil, 0, true},
+	}
+	for _, tt := range tests {
+		got, next, err := storage.PageIndices(sorted, tt.cursor, tt.limit)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("PageIndices(%d, %d) error = %v", tt.cursor, tt.limit, err)
+		}
+		if err == nil && (!bytes.Equal(got, tt.want) || next != tt.next) {
+			t.Fatalf("PageIndices(%d, %d) = %v, %d; want %v, %d", tt.cursor, tt.limit, got, next, tt.want, tt.next)
+		}
+	}
+}
+
+var errOdd = errors.New("odd index rejected")
+
+// evenOnly rejects odd indices.
+type evenOnly struct{ *drivers.MemoryStorage }
+
+func (e evenOnly) SetShare(index byte, share []byte) error {
+	if index%2 == 1 {
+		return errOdd
+	}
+	return e.MemoryStorage.SetShare(index, share)
+}
+
+func TestBatchSetPartial(t *testing.T) {
+	tests := []struct {
+		name    string
+		st      storage.IStorage
+		want    []byte
+		wantErr error
+	}{
+		{"memory", drivers.NewMemoryStorage(), []byte{1, 2, 3, 4}, nil},
+		{"partial failure", evenOnly{drivers.NewMemoryStorage()}, []byte{2, 4}, errOdd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := map[byte][]byte{4: {4}, 3: {3}, 2: {2}, 1: {1}}
+			got, err := storage.BatchSetPartial(tt.st, batch)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("BatchSetPartial error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("BatchSetPartial succeeded = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListSharesPaged(t *testing.T) {
+	for _, st := range []storage.IStorage{drivers.NewMemoryStorage(), sliceStorage{drivers.NewMemoryStorage()}} {
+		for _, idx := range []byte{8, 2, 6, 4} {
+			if err := st.SetShare(idx, []byte{idx}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var all []byte
+		cursor := 0
+		for {
+			page, next, err := storage.ListSharesPaged(st, cursor, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, page...)
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+		if !bytes.Equal(all, []byte{2, 4, 6, 8}) {
+			t.Fatalf("paged listing = %v", all)
+		}
+	}
+}
+
+func TestBatchDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   func() storage.IStorage
+		wantErr error
+	}{
+		{"native", func() storage.IStorage { return drivers.NewMemoryStorage() }, storage.ErrNotFound},
+		{"fallback", func() storage.IStorage { return sliceStorage{drivers.NewMemoryStorage()} }, storage.ErrNotFound},
+		{"multi", func() storage.IStorage {
+			ms := storage.NewMultiStorage()
+			a, b := drivers.NewMemoryStorage(), drivers.NewMemoryStorage()
+			for _, idx := range []byte{1, 3, 5} {
+				ms.AssignStorage(idx, a)
+			}
+			for _, idx := range []byte{2, 4} {
+				ms.AssignStorage(idx, b)
+			}
+			return ms
+		}, storage.ErrNoBackend},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := tt.store()
+			for _, idx := range []byte{1, 2, 3, 4} {
+				if err := st.SetShare(idx, []byte{idx}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := storage.BatchDelete(st, []byte{1, 2, 4, 5, 6})
+			if !errors.Is(err, storage.ErrNotFound) {
+				t.Fatalf("error = %v, want ErrNotFound for index 5", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			for _, idx := range []byte{1, 2, 4} {
+				if _, err := st.GetShare(idx); !errors.Is(err, storage.ErrNotFound) {
+					t.Errorf("share %d survived: %v", idx, err)
+				}
+			}
+			if got, err := st.GetShare(3); err != nil || !bytes.Equal(got, []byte{3}) {
+				t.Errorf("share 3 = %v, %v, want it untouched", got, err)
+			}
+		})
+	}
+}
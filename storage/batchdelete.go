@@ -0,0 +1,57 @@
+// storage/batchdelete.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// BatchDeleter is implemented by storages that can delete several shares in
+// one operation, e.g. a single transaction or request.
+type BatchDeleter interface {
+	BatchDelete(indices []byte) error
+}
+
+// BatchDelete deletes every listed index, continuing past failures. Indices
+// that weren't found and any other per-index failures are joined into the
+// returned error, so errors.Is(err, ErrNotFound) reports whether any index
+// was missing. It uses st's own implementation when available and falls back
+// to DeleteShare.
+func BatchDelete(st IStorage, indices []byte) error {
+	if d, ok := st.(BatchDeleter); ok {
+		return d.BatchDelete(indices)
+	}
+	var errs []error
+	for _, idx := range indices {
+		if err := st.DeleteShare(idx); err != nil {
+			errs = append(errs, fmt.Errorf("shamir: share %d: %w", idx, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BatchDelete groups the indices by assigned backend and deletes each group
+// with one BatchDelete call. Unassigned indices fail with ErrNoBackend.
+func (ms *MultiStorage) BatchDelete(indices []byte) error {
+	var errs []error
+	var groups []backendGroup
+	ms.mu.RLock()
+	for _, idx := range indices {
+		b, ok := ms.backends[idx]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %d", ErrNoBackend, idx))
+			continue
+		}
+		g := findGroup(&groups, b)
+		g.indices = append(g.indices, idx)
+	}
+	ms.mu.RUnlock()
+	for _, g := range groups {
+		sort.Slice(g.indices, func(i, j int) bool { return g.indices[i] < g.indices[j] })
+		if err := BatchDelete(g.backend, g.indices); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+// storage/cache.go
+package storage
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CacheStats reports cache effectiveness.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// CachedStorage keeps recently read shares in an in-memory LRU in front of a
+// durable storage. Writes go through to the durable storage before the
+// cache is updated; evictions never touch the durable storage.
+type CachedStorage struct {
+	durable  IStorage
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List // front = most recently used; values are *cacheEntry
+	entries map[byte]*list.Element
+	stats   CacheStats
+	gen     [256]uint64 // bumped by every write or delete of an index
+}
+
+type cacheEntry struct {
+	index byte
+	share []byte
+}
+
+// NewCachedStorage wraps durable with an LRU of up to capacity shares.
+// A capacity below 1 is treated as 1.
+func NewCachedStorage(durable IStorage, capacity int) *CachedStorage {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &CachedStorage{
+		durable:  durable,
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[byte]*list.Element),
+	}
+}
+
+// Stats returns a snapshot of the cache counters.
+func (cs *CachedStorage) Stats() CacheStats {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	s := cs.stats
+	s.Size = cs.order.Len()
+	return s
+}
+
+// put inserts or refreshes a cached share. Caller holds cs.mu.
+func (cs *CachedStorage) put(index byte, share []byte) {
+	c := make([]byte, len(share))
+	copy(c, share)
+	if el, ok := cs.entries[index]; ok {
+		el.Value.(*cacheEntry).share = c
+		cs.order.MoveToFront(el)
+		return
+	}
+	cs.entries[index] = cs.order.PushFront(&cacheEntry{index: index, share: c})
+	for cs.order.Len() > cs.capacity {
+		oldest := cs.order.Back()
+		cs.order.Remove(oldest)
+		delete(cs.entries, oldest.Value.(*cacheEntry).index)
+		cs.stats.Evictions++
+	}
+}
+
+// drop removes a cached share. Caller holds cs.mu.
+func (cs *CachedStorage) drop(index byte) {
+	if el, ok := cs.entries[index]; ok {
+		cs.order.Remove(el)
+		delete(cs.entries, index)
+	}
+}
+
+// SetShare writes through to durable storage, then refreshes the cache
+// entry if the index is cached.
+func (cs *CachedStorage) SetShare(index byte, share []byte) error {
+	if err := cs.durable.SetShare(index, share); err != nil {
+		cs.mu.Lock()
+		cs.gen[index]++
+		cs.drop(index)
+		cs.mu.Unlock()
+		return err
+	}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.gen[index]++
+	if _, ok := cs.entries[index]; ok {
+		cs.put(index, share)
+	}
+	return nil
+}
+
+// GetShare serves from the cache when possible, otherwise reads durable
+// storage and caches the result. The result is not cached if the index was
+// written or deleted while it was being read, since it may be stale.
+func (cs *CachedStorage) GetShare(index byte) ([]byte, error) {
+	cs.mu.Lock()
+	if el, ok := cs.entries[index]; ok {
+		cs.order.MoveToFront(el)
+		cs.stats.Hits++
+		share := el.Value.(*cacheEntry).share
+		c := make([]byte, len(share))
+		copy(c, share)
+		cs.mu.Unlock()
+		return c, nil
+	}
+	cs.stats.Misses++
+	gen := cs.gen[index]
+	cs.mu.Unlock()
+
+	share, err := cs.durable.GetShare(index)
+	if err != nil {
+		return nil, err
+	}
+	cs.mu.Lock()
+	if cs.gen[index] == gen {
+		cs.put(index, share)
+	}
+	cs.mu.Unlock()
+	return share, nil
+}
+
+// ListShares lists the indices held by durable storage.
+func (cs *CachedStorage) ListShares() ([]byte, error) {
+	return cs.durable.ListShares()
+}
+
+// DeleteShare deletes from durable storage and evicts the cached copy.
+func (cs *CachedStorage) DeleteShare(index byte) error {
+	err := cs.durable.DeleteShare(index)
+	cs.mu.Lock()
+	cs.gen[index]++
+	cs.drop(index)
+	cs.mu.Unlock()
+	return err
+}
+
+// BatchSet writes the batch through to durable storage and invalidates any
+// cached copies of the written indices.
+func (cs *CachedStorage) BatchSet(shares map[byte][]byte) error {
+	err := cs.durable.BatchSet(shares)
+	cs.mu.Lock()
+	for idx := range shares {
+		cs.gen[idx]++
+		cs.drop(idx)
+	}
+	cs.mu.Unlock()
+	return err
+}
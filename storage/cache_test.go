@@ -0,0 +1,97 @@
+// storage/cache_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+// gatedStorage blocks GetShare until release is closed, after signalling
+// on reading that the durable read has happened.
+type gatedStorage struct {
+	*drivers.MemoryStorage
+	reading chan struct{}
+	release chan struct{}
+}
+
+func (g *gatedStorage) GetShare(index byte) ([]byte, error) {
+	share, err := g.MemoryStorage.GetShare(index)
+	g.reading <- struct{}{}
+	<-g.release
+	return share, err
+}
+
+func TestCachedStorageStaleRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(cs *storage.CachedStorage) error
+		want  []byte // nil means the index must be gone
+	}{
+		{"SetShare", func(cs *storage.CachedStorage) error { return cs.SetShare(1, []byte("new")) }, []byte("new")},
+		{"BatchSet", func(cs *storage.CachedStorage) error { return cs.BatchSet(map[byte][]byte{1: []byte("new")}) }, []byte("new")},
+		{"DeleteShare", func(cs *storage.CachedStorage) error { return cs.DeleteShare(1) }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			durable := &gatedStorage{
+				MemoryStorage: drivers.NewMemoryStorage(),
+				reading:       make(chan struct{}),
+				release:       make(chan struct{}),
+			}
+			if err := durable.SetShare(1, []byte("old")); err != nil {
+				t.Fatal(err)
+			}
+			cs := storage.NewCachedStorage(durable, 4)
+
+			done := make(chan error)
+			go func() {
+				_, err := cs.GetShare(1)
+				done <- err
+			}()
+			<-durable.reading // the miss has read "old"
+			if err := tt.write(cs); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			close(durable.release)
+			if err := <-done; err != nil {
+				t.Fatalf("GetShare: %v", err)
+			}
+
+			go func() { <-durable.reading }() // a second miss reads durably
+			got, err := cs.GetShare(1)
+			if tt.want == nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					t.Fatalf("GetShare after delete = %q, %v; want ErrNotFound", got, err)
+				}
+				return
+			}
+			if err != nil || !bytes.Equal(got, tt.want) {
+				t.Fatalf("GetShare = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachedStorageHitsAndEvictions(t *testing.T) {
+	cs := storage.NewCachedStorage(drivers.NewMemoryStorage(), 2)
+	for idx := byte(1); idx <= 3; idx++ {
+		if err := cs.SetShare(idx, []byte{idx}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cs.GetShare(idx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cs.GetShare(3); err != nil {
+		t.Fatal(err)
+	}
+	got := cs.Stats()
+	want := storage.CacheStats{Hits: 1, Misses: 3, Evictions: 1, Size: 2}
+	if got != want {
+		t.Fatalf("Stats = %+v, want %+v", got, want)
+	}
+}
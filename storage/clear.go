@@ -0,0 +1,59 @@
+// storage/clear.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Clearer is implemented by storages that can remove every share in one
+// operation, atomically where the backend allows it.
+type Clearer interface {
+	DeleteAll() error
+}
+
+// DeleteAll removes every share from st, using st's own implementation when
+// available and otherwise deleting the listed indices one by one. Indices
+// that disappear concurrently are not an error.
+func DeleteAll(st IStorage) error {
+	if c, ok := st.(Clearer); ok {
+		return c.DeleteAll()
+	}
+	indices, err := st.ListShares()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, idx := range indices {
+		if err := st.DeleteShare(idx); err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("shamir: share %d: %w", idx, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteAll deletes every assigned index from its backend. Backends are not
+// cleared wholesale, since they may hold shares not routed through ms, and
+// the assignments themselves are kept. Indices already missing from their
+// backend are skipped.
+func (ms *MultiStorage) DeleteAll() error {
+	ms.mu.RLock()
+	assigned := make(map[byte]IStorage, len(ms.backends))
+	for idx, b := range ms.backends {
+		assigned[idx] = b
+	}
+	ms.mu.RUnlock()
+	var errs []error
+	indices := make([]byte, 0, len(assigned))
+	for idx := range assigned {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	for _, idx := range indices {
+		if err := assigned[idx].DeleteShare(idx); err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("shamir: share %d: %w", idx, err))
+		}
+	}
+	return errors.Join(errs...)
+}
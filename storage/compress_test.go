@@ -0,0 +1,109 @@
+// storage/compress_test.go
+package storage_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+func TestCompressedStorageConformance(t *testing.T) {
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		return storage.WithCompression(drivers.NewMemoryStorage(), gzip.BestSpeed)
+	})
+}
+
+func TestCompressedStorageRoundTrip(t *testing.T) {
+	secret := bytes.Repeat([]byte("compressible "), 64)
+	shares, err := shamir.Split(secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	levels := []struct {
+		name  string
+		level int
+	}{
+		{"huffman only", gzip.HuffmanOnly},
+		{"none", gzip.NoCompression},
+		{"best", gzip.BestCompression},
+		{"invalid falls back", 42},
+	}
+	for _, tt := range levels {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := drivers.NewMemoryStorage()
+			cs := storage.WithCompression(inner, tt.level)
+			if err := cs.SetShare(1, shares[0]); err != nil {
+				t.Fatal(err)
+			}
+			if err := cs.BatchSet(map[byte][]byte{2: shares[1], 3: shares[2]}); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := inner.GetShare(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(raw, []byte{0x1f, 0x8b}) {
+				t.Fatalf("inner blob %x is not gzip", raw[:2])
+			}
+			got := make([][]byte, 0, len(shares))
+			for idx := byte(1); idx <= 3; idx++ {
+				s, err := cs.GetShare(idx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(s, shares[idx-1]) {
+					t.Fatalf("share %d changed in the round trip", idx)
+				}
+				got = append(got, s)
+			}
+			combined, err := shamir.Combine(got[1:])
+			if err != nil || !bytes.Equal(combined, secret) {
+				t.Fatalf("Combine = %q, %v", combined, err)
+			}
+		})
+	}
+}
+
+func TestCompressedStorageCorrupt(t *testing.T) {
+	inner := drivers.NewMemoryStorage()
+	cs := storage.WithCompression(inner, gzip.DefaultCompression)
+	if err := cs.SetShare(1, []byte("a share worth compressing")); err != nil {
+		t.Fatal(err)
+	}
+	good, err := inner.GetShare(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flipped := bytes.Clone(good)
+	flipped[len(flipped)-6] ^= 0xff // inside the gzip CRC-32 trailer
+
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{"not gzip", []byte("plain bytes")},
+		{"empty", nil},
+		{"truncated", good[:len(good)/2]},
+		{"flipped trailer byte", flipped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := inner.SetShare(2, tt.blob); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := cs.GetShare(2); !errors.Is(err, storage.ErrCorruptCompressed) {
+				t.Fatalf("GetShare = %v, want ErrCorruptCompressed", err)
+			}
+		})
+	}
+
+	if _, err := cs.GetShare(9); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("GetShare of a missing index = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,63 @@
+// storage/context.go
+package storage
+
+import "context"
+
+// ContextStorage is the context-aware counterpart of IStorage. Backends and
+// decorators that can honour cancellation or deadlines implement it.
+type ContextStorage interface {
+	SetShareContext(ctx context.Context, index byte, share []byte) error
+	GetShareContext(ctx context.Context, index byte) ([]byte, error)
+	ListSharesContext(ctx context.Context) ([]byte, error)
+	DeleteShareContext(ctx context.Context, index byte) error
+	BatchSetContext(ctx context.Context, shares map[byte][]byte) error
+}
+
+// AsContextStorage returns st as a ContextStorage. Backends that don't
+// implement it natively are adapted so the context is checked before each call.
+func AsContextStorage(st IStorage) ContextStorage {
+	if cs, ok := st.(ContextStorage); ok {
+		return cs
+	}
+	return contextAdapter{st}
+}
+
+// contextAdapter lifts a plain IStorage into a ContextStorage.
+type contextAdapter struct {
+	inner IStorage
+}
+
+func (a contextAdapter) SetShareContext(ctx context.Context, index byte, share []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.inner.SetShare(index, share)
+}
+
+func (a contextAdapter) GetShareContext(ctx context.Context, index byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.inner.GetShare(index)
+}
+
+func (a contextAdapter) ListSharesContext(ctx context.Context) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.inner.ListShares()
+}
+
+func (a contextAdapter) DeleteShareContext(ctx context.Context, index byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.inner.DeleteShare(index)
+}
+
+func (a contextAdapter) BatchSetContext(ctx context.Context, shares map[byte][]byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.inner.BatchSet(shares)
+}
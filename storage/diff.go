@@ -0,0 +1,69 @@
+// storage/diff.go
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// StorageDiff describes how the shares held by two storages differ.
+// Every slice is sorted in ascending index order.
+type StorageDiff struct {
+	OnlyInA   []byte // indices present only in A
+	OnlyInB   []byte // indices present only in B
+	Differing []byte // indices present in both with different bytes
+	Equal     []byte // indices present in both with identical bytes
+}
+
+// InSync reports whether both storages hold exactly the same shares.
+func (d StorageDiff) InSync() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Differing) == 0
+}
+
+// DiffStorage compares the shares held by a and b.
+func DiffStorage(a, b IStorage) (StorageDiff, error) {
+	var diff StorageDiff
+	aIdx, err := a.ListShares()
+	if err != nil {
+		return diff, fmt.Errorf("shamir: list A: %w", err)
+	}
+	bIdx, err := b.ListShares()
+	if err != nil {
+		return diff, fmt.Errorf("shamir: list B: %w", err)
+	}
+	inB := make(map[byte]bool, len(bIdx))
+	for _, idx := range bIdx {
+		inB[idx] = true
+	}
+	inA := make(map[byte]bool, len(aIdx))
+	for _, idx := range aIdx {
+		inA[idx] = true
+		if !inB[idx] {
+			diff.OnlyInA = append(diff.OnlyInA, idx)
+			continue
+		}
+		sa, err := a.GetShare(idx)
+		if err != nil {
+			return diff, fmt.Errorf("shamir: get share %d from A: %w", idx, err)
+		}
+		sb, err := b.GetShare(idx)
+		if err != nil {
+			return diff, fmt.Errorf("shamir: get share %d from B: %w", idx, err)
+		}
+		if bytes.Equal(sa, sb) {
+			diff.Equal = append(diff.Equal, idx)
+		} else {
+			diff.Differing = append(diff.Differing, idx)
+		}
+	}
+	for _, idx := range bIdx {
+		if !inA[idx] {
+			diff.OnlyInB = append(diff.OnlyInB, idx)
+		}
+	}
+	for _, s := range [][]byte{diff.OnlyInA, diff.OnlyInB, diff.Differing, diff.Equal} {
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	}
+	return diff, nil
+}
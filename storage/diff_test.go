@@ -0,0 +1,80 @@
+// storage/diff_test.go
+package storage_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestDiffStorage(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   map[byte]string
+		want   storage.StorageDiff
+		inSync bool
+	}{
+		{
+			name: "every category",
+			a:    map[byte]string{1: "one", 2: "two", 3: "three", 9: "nine", 4: "four"},
+			b:    map[byte]string{2: "two", 3: "THREE", 5: "five", 7: "seven", 4: "four"},
+			want: storage.StorageDiff{
+				OnlyInA:   []byte{1, 9},
+				OnlyInB:   []byte{5, 7},
+				Differing: []byte{3},
+				Equal:     []byte{2, 4},
+			},
+		},
+		{
+			name:   "in sync",
+			a:      map[byte]string{1: "x", 2: "y"},
+			b:      map[byte]string{2: "y", 1: "x"},
+			want:   storage.StorageDiff{Equal: []byte{1, 2}},
+			inSync: true,
+		},
+		{
+			name:   "both empty",
+			inSync: true,
+		},
+		{
+			name: "one side empty",
+			b:    map[byte]string{6: "six"},
+			want: storage.StorageDiff{OnlyInB: []byte{6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := drivers.NewMemoryStorage(), drivers.NewMemoryStorage()
+			for idx, s := range tt.a {
+				_ = a.SetShare(idx, []byte(s))
+			}
+			for idx, s := range tt.b {
+				_ = b.SetShare(idx, []byte(s))
+			}
+			got, err := storage.DiffStorage(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DiffStorage = %+v, want %+v", got, tt.want)
+			}
+			if got.InSync() != tt.inSync {
+				t.Fatalf("InSync = %v, want %v", got.InSync(), tt.inSync)
+			}
+		})
+	}
+}
+
+func TestDiffStorageReadError(t *testing.T) {
+	a := &downStorage{MemoryStorage: drivers.NewMemoryStorage()}
+	b := drivers.NewMemoryStorage()
+	_ = a.SetShare(1, []byte("x"))
+	_ = b.SetShare(1, []byte("x"))
+	a.down = true
+	if _, err := storage.DiffStorage(a, b); !errors.Is(err, errDown) {
+		t.Fatalf("DiffStorage error = %v, want %v", err, errDown)
+	}
+}
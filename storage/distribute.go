@@ -0,0 +1,35 @@
+// storage/distribute.go
+package storage
+
+import (
+	"fmt"
+
+	"github.com/oarkflow/shamir"
+)
+
+// SplitAndDistribute splits the secret into len(assignment) shares with
+// threshold t and stores share i in assignment[i]. The assignment must cover
+// exactly the indices 1..n, which are the indices Split produces.
+func SplitAndDistribute(secret []byte, t int, assignment map[byte]IStorage) ([][]byte, error) {
+	n := len(assignment)
+	if n < t || n > 255 {
+		return nil, fmt.Errorf("shamir: %d assignments, need between threshold %d and 255", n, t)
+	}
+	for i := 1; i <= n; i++ {
+		if assignment[byte(i)] == nil {
+			return nil, fmt.Errorf("%w: %d", ErrNoBackend, i)
+		}
+	}
+	shares, err := shamir.Split(secret, t, n)
+	if err != nil {
+		return nil, err
+	}
+	ms := NewMultiStorage()
+	for idx, backend := range assignment {
+		ms.AssignStorage(idx, backend)
+	}
+	if err := StoreSharesMulti(shares, ms); err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
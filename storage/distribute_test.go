@@ -0,0 +1,84 @@
+// storage/distribute_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestSplitAndDistribute(t *testing.T) {
+	secret := []byte("spread across backends")
+	mem := drivers.NewMemoryStorage()
+	fs, err := drivers.NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignment := map[byte]storage.IStorage{1: mem, 2: fs, 3: mem, 4: fs}
+
+	shares, err := storage.SplitAndDistribute(secret, 3, assignment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != len(assignment) {
+		t.Fatalf("%d shares, want %d", len(shares), len(assignment))
+	}
+	if got, _ := mem.ListSharesSorted(); !bytes.Equal(got, []byte{1, 3}) {
+		t.Fatalf("memory backend holds %v, want [1 3]", got)
+	}
+	if got, _ := fs.ListShares(); len(got) != 2 {
+		t.Fatalf("file backend holds %v, want [2 4]", got)
+	}
+	stored := make([][]byte, 0, len(assignment))
+	for idx, backend := range assignment {
+		s, err := backend.GetShare(idx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(s, shares[idx-1]) {
+			t.Fatalf("backend for %d holds a different share", idx)
+		}
+		stored = append(stored, s)
+	}
+	combined, err := shamir.Combine(stored[:3])
+	if err != nil || !bytes.Equal(combined, secret) {
+		t.Fatalf("Combine = %q, %v", combined, err)
+	}
+}
+
+func TestSplitAndDistributeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       int
+		indices []byte
+		errIs   error
+	}{
+		{"fewer assignments than threshold", 3, []byte{1, 2}, nil},
+		{"gap in indices", 2, []byte{1, 2, 4}, storage.ErrNoBackend},
+		{"index zero", 2, []byte{0, 1, 2}, storage.ErrNoBackend},
+		{"threshold too small", 1, []byte{1, 2}, shamir.ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := drivers.NewMemoryStorage()
+			assignment := make(map[byte]storage.IStorage, len(tt.indices))
+			for _, idx := range tt.indices {
+				assignment[idx] = mem
+			}
+			_, err := storage.SplitAndDistribute([]byte("secret"), tt.t, assignment)
+			if err == nil {
+				t.Fatal("SplitAndDistribute succeeded")
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Fatalf("error = %v, want %v", err, tt.errIs)
+			}
+			if left, _ := mem.ListShares(); len(left) != 0 {
+				t.Fatalf("shares %v stored despite the error", left)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// storage/drivers/age.go
+package drivers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+var _ storage.IStorage = (*AgeFileStorage)(nil)
+
+// AgeFileStorage implements IStorage by writing each share to an
+// age-encrypted file named share_<index>.dat.age. The files can be inspected
+// or recovered with the age command-line tool using the same identities.
+type AgeFileStorage struct {
+	dir        string
+	recipients []age.Recipient
+	identities []age.Identity
+	mu         sync.RWMutex
+}
+
+// NewAgeFileStorage ensures the directory exists. Shares are encrypted to
+// every recipient and decrypted with whichever identity matches. identities
+// may be empty for a write-only store.
+func NewAgeFileStorage(dir string, recipients []age.Recipient, identities []age.Identity) (*AgeFileStorage, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("agestorage: at least one recipient is required")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &AgeFileStorage{dir: dir, recipients: recipients, identities: identities}, nil
+}
+
+func (as *AgeFileStorage) filePath(index byte) string {
+	return filepath.Join(as.dir, fmt.Sprintf("share_%d.dat.age", index))
+}
+
+func (as *AgeFileStorage) SetShare(index byte, share []byte) error {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, as.recipients...)
+	if err != nil {
+		return fmt.Errorf("agestorage: encrypt share %d: %w", index, err)
+	}
+	if _, err := w.Write(share); err != nil {
+		return fmt.Errorf("agestorage: encrypt share %d: %w", index, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("agestorage: encrypt share %d: %w", index, err)
+	}
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	return os.WriteFile(as.filePath(index), buf.Bytes(), 0600)
+}
+
+func (as *AgeFileStorage) GetShare(index byte) ([]byte, error) {
+	as.mu.RLock()
+	data, err := os.ReadFile(as.filePath(index))
+	as.mu.RUnlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("agestorage: %w", storage.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("agestorage: read share %d: %w", index, err)
+	}
+	if len(as.identities) == 0 {
+		return nil, errors.New("agestorage: no identities configured for decryption")
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), as.identities...)
+	if err != nil {
+		return nil, fmt.Errorf("agestorage: decrypt share %d: %w", index, err)
+	}
+	share, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("agestorage: decrypt share %d: %w", index, err)
+	}
+	return share, nil
+}
+
+func (as *AgeFileStorage) ListShares() ([]byte, error) {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+	entries, err := os.ReadDir(as.dir)
+	if err != nil {
+		return nil, err
+	}
+	var indices []byte
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, "share_") || !strings.HasSuffix(name, ".dat.age") {
+			continue
+		}
+		num := strings.TrimSuffix(strings.TrimPrefix(name, "share_"), ".dat.age")
+		i, err := strconv.Atoi(num)
+		if err != nil || i < 1 || i > 255 {
+			continue
+		}
+		indices = append(indices, byte(i))
+	}
+	return indices, nil
+}
+
+func (as *AgeFileStorage) DeleteShare(index byte) error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	if err := os.Remove(as.filePath(index)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("agestorage: %w", storage.ErrNotFound)
+		}
+		return errors.New("agestorage: could not delete share")
+	}
+	return nil
+}
+
+func (as *AgeFileStorage) BatchSet(shares map[byte][]byte) error {
+	for _, idx := range storage.SortedIndices(shares) {
+		if err := as.SetShare(idx, shares[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteAll removes every encrypted share file from the directory, leaving
+// any other files in place.
+func (as *AgeFileStorage) DeleteAll() error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	if err := removeShareFiles(osFS{}, as.dir, ".dat.age"); err != nil {
+		return fmt.Errorf("agestorage: delete all: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// storage/drivers/conformance_test.go
+package drivers
+
+import (
+	"testing"
+
+	"filippo.io/age"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+func TestMemoryStorageConformance(t *testing.T) {
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		return NewMemoryStorage()
+	})
+}
+
+func TestFileStorageConformance(t *testing.T) {
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		fs, err := NewFileStorage(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fs
+	})
+}
+
+func TestAgeFileStorageConformance(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		as, err := NewAgeFileStorage(t.TempDir(), []age.Recipient{id.Recipient()}, []age.Identity{id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return as
+	})
+}
@@ -6,23 +6,68 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
 )
 
+var _ storage.IStorage = (*FileStorage)(nil)
+
 // FileStorage implements IStorage by writing each share to a file.
 type FileStorage struct {
-	dir string
-	mu  sync.RWMutex
+	dir  string
+	opts FileStorageOptions
+	fsys fileSystem
+	mu   sync.RWMutex
+}
+
+// FileStorageOptions tunes a FileStorage.
+type FileStorageOptions struct {
+	// OpTimeout bounds each operation, for directories on network
+	// filesystems where a read or write can block indefinitely. An
+	// operation that overruns returns an error wrapping
+	// os.ErrDeadlineExceeded. The syscall itself cannot be cancelled: it
+	// keeps running in the background and holds the storage lock until it
+	// finishes, so later operations time out too until the filesystem
+	// recovers. Zero means no timeout.
+	OpTimeout time.Duration
 }
 
 // NewFileStorage ensures the directory exists.
 func NewFileStorage(dir string) (*FileStorage, error) {
+	return NewFileStorageWithOptions(dir, FileStorageOptions{})
+}
+
+// NewFileStorageWithOptions ensures the directory exists and applies opts.
+// The directory is created without a timeout.
+func NewFileStorageWithOptions(dir string, opts FileStorageOptions) (*FileStorage, error) {
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return nil, err
 	}
-	return &FileStorage{dir: dir}, nil
+	return &FileStorage{dir: dir, opts: opts, fsys: osFS{}}, nil
+}
+
+// guard runs fn, giving up after OpTimeout. fn must do its own locking so
+// that an abandoned operation keeps the lock until its syscall returns.
+func (fs *FileStorage) guard(op string, fn func() error) error {
+	if fs.opts.OpTimeout <= 0 {
+		return fn()
+	}
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+	timer := time.NewTimer(fs.opts.OpTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("filestorage: %s timed out after %v: %w", op, fs.opts.OpTimeout, os.ErrDeadlineExceeded)
+	}
 }
 
 func (fs *FileStorage) filePath(index byte) string {
@@ -30,25 +75,50 @@ func (fs *FileStorage) filePath(index byte) string {
 }
 
 func (fs *FileStorage) SetShare(index byte, share []byte) error {
-	fs.mu.Lock()
-	defer fs.mu.Unlock()
-	return os.WriteFile(fs.filePath(index), share, 0600)
+	if fs.opts.OpTimeout > 0 {
+		// the write may outlive this call, so don't let it see later changes
+		share = append([]byte(nil), share...)
+	}
+	return fs.guard("write", func() error {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		return fs.fsys.WriteFile(fs.filePath(index), share, 0600)
+	})
 }
 
 func (fs *FileStorage) GetShare(index byte) ([]byte, error) {
-	fs.mu.RLock()
-	defer fs.mu.RUnlock()
-	data, err := os.ReadFile(fs.filePath(index))
+	var data []byte
+	err := fs.guard("read", func() error {
+		fs.mu.RLock()
+		defer fs.mu.RUnlock()
+		d, err := fs.fsys.ReadFile(fs.filePath(index))
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("filestorage: %w", storage.ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("filestorage: read share %d: %w", index, err)
+		}
+		data = d
+		return nil
+	})
 	if err != nil {
-		return nil, errors.New("filestorage: share not found")
+		return nil, err
 	}
 	return data, nil
 }
 
 func (fs *FileStorage) ListShares() ([]byte, error) {
-	fs.mu.RLock()
-	defer fs.mu.RUnlock()
-	entries, err := os.ReadDir(fs.dir)
+	var entries []os.DirEntry
+	err := fs.guard("list", func() error {
+		fs.mu.RLock()
+		defer fs.mu.RUnlock()
+		e, err := fs.fsys.ReadDir(fs.dir)
+		if err != nil {
+			return err
+		}
+		entries = e
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -69,13 +139,41 @@ func (fs *FileStorage) ListShares() ([]byte, error) {
 }
 
 func (fs *FileStorage) DeleteShare(index byte) error {
-	fs.mu.Lock()
-	defer fs.mu.Unlock()
-	path := fs.filePath(index)
-	if err := os.Remove(path); err != nil {
-		return errors.New("filestorage: share not found or could not delete")
+	return fs.guard("delete", func() error {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		path := fs.filePath(index)
+		if err := fs.fsys.Remove(path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("filestorage: %w", storage.ErrNotFound)
+			}
+			return errors.New("filestorage: could not delete share")
+		}
+		return nil
+	})
+}
+
+// BatchDelete removes the listed share files in one guarded operation,
+// reporting every index that wasn't found.
+func (fs *FileStorage) BatchDelete(indices []byte) error {
+	var errs []error
+	err := fs.guard("batch delete", func() error {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		for _, idx := range indices {
+			if err := fs.fsys.Remove(fs.filePath(idx)); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					err = storage.ErrNotFound
+				}
+				errs = append(errs, fmt.Errorf("filestorage: share %d: %w", idx, err))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (fs *FileStorage) BatchSet(shares map[byte][]byte) error {
@@ -86,3 +184,100 @@ func (fs *FileStorage) BatchSet(shares map[byte][]byte) error {
 	}
 	return nil
 }
+
+// ListSharesSorted lists stored indices in ascending order.
+func (fs *FileStorage) ListSharesSorted() ([]byte, error) {
+	indices, err := fs.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
+}
+
+// ListSharesPaged lists one page of stored indices in ascending order.
+func (fs *FileStorage) ListSharesPaged(cursor, limit int) ([]byte, int, error) {
+	indices, err := fs.ListSharesSorted()
+	if err != nil {
+		return nil, 0, err
+	}
+	return storage.PageIndices(indices, cursor, limit)
+}
+
+// Validate reads every file in the directory and reports which share files
+// hold well-formed shares. Files that aren't named share_<index>.dat, fail to
+// parse, fail their CRC, or carry a different index than their name are
+// returned in invalid keyed by file name.
+func (fs *FileStorage) Validate() ([]byte, map[string]error, error) {
+	// the results are only read once guard reports that fn has finished;
+	// after a timeout fn may still be running and writing them
+	var valid []byte
+	var invalid map[string]error
+	err := fs.guard("validate", func() error {
+		v, inv, err := fs.validate()
+		if err != nil {
+			return err
+		}
+		valid, invalid = v, inv
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valid, invalid, nil
+}
+
+func (fs *FileStorage) validate() (valid []byte, invalid map[string]error, err error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	entries, err := fs.fsys.ReadDir(fs.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	invalid = make(map[string]error)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if !strings.HasPrefix(name, "share_") || !strings.HasSuffix(name, ".dat") {
+			invalid[name] = errors.New("filestorage: not a share file")
+			continue
+		}
+		num := strings.TrimSuffix(strings.TrimPrefix(name, "share_"), ".dat")
+		i, err := strconv.Atoi(num)
+		if err != nil || i < 0 || i > 255 {
+			invalid[name] = errors.New("filestorage: bad share index in file name")
+			continue
+		}
+		data, err := fs.fsys.ReadFile(filepath.Join(fs.dir, name))
+		if err != nil {
+			invalid[name] = err
+			continue
+		}
+		if err := shamir.ValidateShare(data); err != nil {
+			invalid[name] = err
+			continue
+		}
+		if data[9] != byte(i) {
+			invalid[name] = fmt.Errorf("filestorage: file holds share %d", data[9])
+			continue
+		}
+		valid = append(valid, byte(i))
+	}
+	sort.Slice(valid, func(a, b int) bool { return valid[a] < valid[b] })
+	return valid, invalid, nil
+}
+
+// DeleteAll removes every share file from the directory, leaving any other
+// files in place.
+func (fs *FileStorage) DeleteAll() error {
+	return fs.guard("delete all", func() error {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		if err := removeShareFiles(fs.fsys, fs.dir, ".dat"); err != nil {
+			return fmt.Errorf("filestorage: delete all: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,192 @@
+// storage/drivers/file_test.go
+package drivers
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
+)
+
+// slowFS blocks every call until release is closed, like a hung network
+// filesystem.
+type slowFS struct {
+	osFS
+	release chan struct{}
+}
+
+func (s slowFS) ReadFile(name string) ([]byte, error) {
+	<-s.release
+	return s.osFS.ReadFile(name)
+}
+
+func (s slowFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	<-s.release
+	return s.osFS.WriteFile(name, data, perm)
+}
+
+func (s slowFS) ReadDir(name string) ([]os.DirEntry, error) {
+	<-s.release
+	return s.osFS.ReadDir(name)
+}
+
+func (s slowFS) Remove(name string) error {
+	<-s.release
+	return s.osFS.Remove(name)
+}
+
+func TestFileStorageOpTimeout(t *testing.T) {
+	fs, err := NewFileStorageWithOptions(t.TempDir(), FileStorageOptions{OpTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SetShare(1, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	fs.fsys = slowFS{release: release}
+
+	ops := []struct {
+		name string
+		run  func() error
+	}{
+		{"GetShare", func() error { _, err := fs.GetShare(1); return err }},
+		{"SetShare", func() error { return fs.SetShare(2, []byte{2}) }},
+		{"ListShares", func() error { _, err := fs.ListShares(); return err }},
+		{"DeleteShare", func() error { return fs.DeleteShare(1) }},
+		{"Validate", func() error { _, _, err := fs.Validate(); return err }},
+	}
+	for _, op := range ops {
+		t.Run(op.name, func(t *testing.T) {
+			start := time.Now()
+			err := op.run()
+			if !errors.Is(err, os.ErrDeadlineExceeded) {
+				t.Fatalf("%s on a hung filesystem: %v, want os.ErrDeadlineExceeded", op.name, err)
+			}
+			if d := time.Since(start); d > time.Second {
+				t.Fatalf("%s returned after %v", op.name, d)
+			}
+		})
+	}
+
+	// once the filesystem recovers, the abandoned operations finish and the
+	// storage works again
+	close(release)
+	if _, err := fs.ListShares(); err != nil {
+		t.Fatalf("ListShares after recovery: %v", err)
+	}
+	if err := fs.SetShare(3, []byte{3}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := fs.GetShare(3)
+	if err != nil || !bytes.Equal(got, []byte{3}) {
+		t.Fatalf("GetShare after recovery = %x, %v", got, err)
+	}
+}
+
+func TestFileStorageValidate(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := shamir.Split([]byte("validate me"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.BatchSet(map[byte][]byte{1: shares[0], 2: shares[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := bytes.Clone(shares[1])
+	corrupt[len(corrupt)-5] ^= 0xff
+	files := map[string][]byte{
+		"share_2.dat": corrupt,
+		"share_3.dat": shares[0],
+		"share_x.dat": shares[2],
+		"notes.txt":   []byte("not a share"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	valid, invalid, err := fs.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(valid, []byte{1}) {
+		t.Fatalf("valid = %v, want [1]", valid)
+	}
+	if len(invalid) != len(files) {
+		t.Fatalf("invalid = %v, want one entry per file in %v", invalid, files)
+	}
+	for name := range files {
+		if invalid[name] == nil {
+			t.Errorf("%s not reported invalid", name)
+		}
+	}
+	if !errors.Is(invalid["share_2.dat"], shamir.ErrCRCMismatch) {
+		t.Errorf("share_2.dat: %v, want ErrCRCMismatch", invalid["share_2.dat"])
+	}
+}
+
+func TestDeleteAllKeepsOtherFiles(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		open func(dir string) (storage.IStorage, error)
+	}{
+		{"file", func(dir string) (storage.IStorage, error) { return NewFileStorage(dir) }},
+		{"age", func(dir string) (storage.IStorage, error) {
+			return NewAgeFileStorage(dir, []age.Recipient{id.Recipient()}, []age.Identity{id})
+		}},
+	}
+	others := []string{"notes.txt", "share_x.dat", "share_1.bak"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			st, err := tt.open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := st.BatchSet(map[byte][]byte{1: {1}, 2: {2}}); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range others {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte("keep"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := storage.DeleteAll(st); err != nil {
+				t.Fatal(err)
+			}
+			if indices, err := st.ListShares(); err != nil || len(indices) != 0 {
+				t.Fatalf("ListShares after DeleteAll = %v, %v", indices, err)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(others) {
+				t.Fatalf("%d files left, want only %v", len(entries), others)
+			}
+			for _, name := range others {
+				if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+					t.Errorf("%s: %v", name, err)
+				}
+			}
+		})
+	}
+}
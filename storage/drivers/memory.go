@@ -3,9 +3,15 @@ package drivers
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
+
+	"github.com/oarkflow/shamir/storage"
 )
 
+var _ storage.IStorage = (*MemoryStorage)(nil)
+
 // MemoryStorage implements IStorage in memory.
 type MemoryStorage struct {
 	mu   sync.RWMutex
@@ -32,7 +38,7 @@ func (ms *MemoryStorage) GetShare(index byte) ([]byte, error) {
 	defer ms.mu.RUnlock()
 	share, ok := ms.data[index]
 	if !ok {
-		return nil, errors.New("memory: share not found")
+		return nil, fmt.Errorf("memory: %w", storage.ErrNotFound)
 	}
 	// return a copy
 	c := make([]byte, len(share))
@@ -54,12 +60,30 @@ func (ms *MemoryStorage) DeleteShare(index byte) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 	if _, ok := ms.data[index]; !ok {
-		return errors.New("memory: share not found")
+		return fmt.Errorf("memory: %w", storage.ErrNotFound)
 	}
 	delete(ms.data, index)
 	return nil
 }
 
+// BatchDelete deletes the listed indices under one lock, reporting every
+// index that wasn't found.
+func (ms *MemoryStorage) BatchDelete(indices []byte) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var errs []error
+	for _, idx := range indices {
+		s, ok := ms.data[idx]
+		if !ok {
+			errs = append(errs, fmt.Errorf("memory: share %d: %w", idx, storage.ErrNotFound))
+			continue
+		}
+		wipeBytes(s)
+		delete(ms.data, idx)
+	}
+	return errors.Join(errs...)
+}
+
 func (ms *MemoryStorage) BatchSet(shares map[byte][]byte) error {
 	for idx, s := range shares {
 		if err := ms.SetShare(idx, s); err != nil {
@@ -68,3 +92,33 @@ func (ms *MemoryStorage) BatchSet(shares map[byte][]byte) error {
 	}
 	return nil
 }
+
+// ListSharesSorted lists stored indices in ascending order.
+func (ms *MemoryStorage) ListSharesSorted() ([]byte, error) {
+	indices, err := ms.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
+}
+
+// ListSharesPaged lists one page of stored indices in ascending order.
+func (ms *MemoryStorage) ListSharesPaged(cursor, limit int) ([]byte, int, error) {
+	indices, err := ms.ListSharesSorted()
+	if err != nil {
+		return nil, 0, err
+	}
+	return storage.PageIndices(indices, cursor, limit)
+}
+
+// DeleteAll removes every share.
+func (ms *MemoryStorage) DeleteAll() error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for _, s := range ms.data {
+		wipeBytes(s)
+	}
+	ms.data = make(map[byte][]byte)
+	return nil
+}
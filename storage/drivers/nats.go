@@ -0,0 +1,134 @@
+// storage/drivers/nats.go
+package drivers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+var _ storage.IStorage = (*NATSStorage)(nil)
+
+// NATSStorage implements IStorage on a JetStream key-value bucket. Each share
+// is stored under its decimal index. The bucket keeps a history of values
+// per key, so earlier versions of a share can be read with
+// GetShareRevision.
+type NATSStorage struct {
+	kv nats.KeyValue
+}
+
+// NewNATSStorage binds to an existing JetStream key-value bucket.
+func NewNATSStorage(js nats.JetStreamContext, bucket string) (*NATSStorage, error) {
+	kv, err := js.KeyValue(bucket)
+	if err != nil {
+		return nil, fmt.Errorf("nats: bucket %q: %w", bucket, err)
+	}
+	return &NATSStorage{kv: kv}, nil
+}
+
+func natsKey(index byte) string {
+	return strconv.Itoa(int(index))
+}
+
+func (ns *NATSStorage) SetShare(index byte, share []byte) error {
+	if _, err := ns.kv.Put(natsKey(index), share); err != nil {
+		return fmt.Errorf("nats: put share %d: %w", index, err)
+	}
+	return nil
+}
+
+func (ns *NATSStorage) GetShare(index byte) ([]byte, error) {
+	e, err := ns.kv.Get(natsKey(index))
+	if err != nil {
+		return nil, natsErr(err)
+	}
+	return e.Value(), nil
+}
+
+// GetShareRevision reads the share as it was stored at a specific bucket
+// revision, e.g. the value held before a refresh overwrote it. Revisions
+// that have been purged or that belong to another key are ErrNotFound.
+func (ns *NATSStorage) GetShareRevision(index byte, revision uint64) ([]byte, error) {
+	e, err := ns.kv.GetRevision(natsKey(index), revision)
+	if err != nil {
+		return nil, natsErr(err)
+	}
+	return e.Value(), nil
+}
+
+// Revision returns the bucket revision of the current value of a share.
+func (ns *NATSStorage) Revision(index byte) (uint64, error) {
+	e, err := ns.kv.Get(natsKey(index))
+	if err != nil {
+		return 0, natsErr(err)
+	}
+	return e.Revision(), nil
+}
+
+func (ns *NATSStorage) ListShares() ([]byte, error) {
+	keys, err := ns.kv.Keys()
+	if errors.Is(err, nats.ErrNoKeysFound) {
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("nats: list shares: %w", err)
+	}
+	indices := make([]byte, 0, len(keys))
+	for _, k := range keys {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 1 || i > 255 {
+			continue
+		}
+		indices = append(indices, byte(i))
+	}
+	return indices, nil
+}
+
+// DeleteShare writes a delete marker for the share. Earlier revisions stay
+// readable through GetShareRevision until the bucket history is purged.
+func (ns *NATSStorage) DeleteShare(index byte) error {
+	if _, err := ns.kv.Get(natsKey(index)); err != nil {
+		return natsErr(err)
+	}
+	if err := ns.kv.Delete(natsKey(index)); err != nil {
+		return fmt.Errorf("nats: delete share %d: %w", index, err)
+	}
+	return nil
+}
+
+func (ns *NATSStorage) BatchSet(shares map[byte][]byte) error {
+	for _, idx := range storage.SortedIndices(shares) {
+		if err := ns.SetShare(idx, shares[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// natsErr maps a missing or deleted key to storage.ErrNotFound.
+func natsErr(err error) error {
+	if errors.Is(err, nats.ErrKeyNotFound) || errors.Is(err, nats.ErrKeyDeleted) {
+		return fmt.Errorf("nats: %w", storage.ErrNotFound)
+	}
+	return fmt.Errorf("nats: %w", err)
+}
+
+// DeleteAll purges every share key, including its revision history. The
+// bucket has no transactions, so a failure part-way leaves some shares.
+func (ns *NATSStorage) DeleteAll() error {
+	indices, err := ns.ListShares()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, idx := range indices {
+		if err := ns.kv.Purge(natsKey(idx)); err != nil {
+			errs = append(errs, fmt.Errorf("nats: purge share %d: %w", idx, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+// storage/drivers/nats_integration_test.go
+
+//go:build integration
+
+package drivers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+// natsJetStream connects to the server at NATS_URL, e.g. one started with
+//
+//	nats-server -js
+//
+// and NATS_URL=nats://127.0.0.1:4222 set. Run with -tags integration.
+func natsJetStream(t *testing.T) nats.JetStreamContext {
+	t.Helper()
+	url := os.Getenv("NATS_URL")
+	if url == "" {
+		t.Skip("NATS_URL not set")
+	}
+	nc, err := nats.Connect(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(nc.Close)
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return js
+}
+
+// newNATSTestStorage creates a fresh bucket that is removed when t ends.
+// It panics rather than calling t.Fatal, since the conformance suite calls
+// it from subtests of t.
+func newNATSTestStorage(t *testing.T, js nats.JetStreamContext) *NATSStorage {
+	bucket := fmt.Sprintf("shamir-test-%d", time.Now().UnixNano())
+	if _, err := js.CreateKeyValue(&nats.KeyValueConfig{Bucket: bucket, History: 5}); err != nil {
+		panic(fmt.Sprintf("create bucket %s: %v", bucket, err))
+	}
+	t.Cleanup(func() { _ = js.DeleteKeyValue(bucket) })
+	st, err := NewNATSStorage(js, bucket)
+	if err != nil {
+		panic(err)
+	}
+	return st
+}
+
+func TestNATSStorageIntegration(t *testing.T) {
+	js := natsJetStream(t)
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		return newNATSTestStorage(t, js)
+	})
+}
+
+func TestNATSStorageRevisions(t *testing.T) {
+	js := natsJetStream(t)
+	st := newNATSTestStorage(t, js)
+	if _, err := NewNATSStorage(js, "shamir-test-missing"); err == nil {
+		t.Fatal("NewNATSStorage bound to a missing bucket")
+	}
+
+	var revs []uint64
+	for _, v := range []string{"first", "second"} {
+		if err := st.SetShare(3, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+		rev, err := st.Revision(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		revs = append(revs, rev)
+	}
+	if err := st.DeleteShare(3); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		read    func() ([]byte, error)
+		want    string
+		wantErr error
+	}{
+		{"current after delete", func() ([]byte, error) { return st.GetShare(3) }, "", storage.ErrNotFound},
+		{"first revision", func() ([]byte, error) { return st.GetShareRevision(3, revs[0]) }, "first", nil},
+		{"second revision", func() ([]byte, error) { return st.GetShareRevision(3, revs[1]) }, "second", nil},
+		{"revision of another key", func() ([]byte, error) { return st.GetShareRevision(4, revs[0]) }, "", storage.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.read()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, []byte(tt.want)) && tt.wantErr == nil {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if indices, err := st.ListShares(); err != nil || len(indices) != 0 {
+		t.Fatalf("ListShares after delete = %v, %v", indices, err)
+	}
+	if err := st.SetShare(5, []byte("five")); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.GetShareRevision(5, 1); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("revision survived DeleteAll: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+// storage/drivers/readerr_test.go
+package drivers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+// TestReadErrorsAreNotNotFound checks that only a missing share file maps to
+// ErrNotFound: an unreadable one (here a directory in its place) must not,
+// or callers that treat NotFound as "empty" would overwrite data.
+func TestReadErrorsAreNotNotFound(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		open func(dir string) (storage.IStorage, error)
+		file string
+	}{
+		{"file", func(dir string) (storage.IStorage, error) { return NewFileStorage(dir) }, "share_1.dat"},
+		{"age", func(dir string) (storage.IStorage, error) {
+			return NewAgeFileStorage(dir, []age.Recipient{id.Recipient()}, []age.Identity{id})
+		}, "share_1.dat.age"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			st, err := tt.open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := st.GetShare(1); !errors.Is(err, storage.ErrNotFound) {
+				t.Fatalf("missing share: %v, want ErrNotFound", err)
+			}
+			if err := os.Mkdir(filepath.Join(dir, tt.file), 0700); err != nil {
+				t.Fatal(err)
+			}
+			_, err = st.GetShare(1)
+			if err == nil || errors.Is(err, storage.ErrNotFound) {
+				t.Fatalf("unreadable share: %v, want a non-NotFound error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// storage/encoding.go
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// Codec converts shares to and from their at-rest representation.
+type Codec interface {
+	Encode(share []byte) []byte
+	Decode(data []byte) ([]byte, error)
+}
+
+type base64Codec struct{}
+
+func (base64Codec) Encode(share []byte) []byte {
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(share)))
+	base64.StdEncoding.Encode(out, share)
+	return out
+}
+
+func (base64Codec) Decode(data []byte) ([]byte, error) {
+	out := make([]byte, base64.StdEncoding.DecodedLen(len(data)))
+	n, err := base64.StdEncoding.Decode(out, data)
+	return out[:n], err
+}
+
+type hexCodec struct{}
+
+func (hexCodec) Encode(share []byte) []byte {
+	out := make([]byte, hex.EncodedLen(len(share)))
+	hex.Encode(out, share)
+	return out
+}
+
+func (hexCodec) Decode(data []byte) ([]byte, error) {
+	out := make([]byte, hex.DecodedLen(len(data)))
+	n, err := hex.Decode(out, data)
+	return out[:n], err
+}
+
+var (
+	// Base64Codec stores shares as standard base64 text.
+	Base64Codec Codec = base64Codec{}
+	// HexCodec stores shares as lowercase hex text.
+	HexCodec Codec = hexCodec{}
+)
+
+// EncodingStorage stores shares in an encoded (e.g. text) form while
+// exposing raw binary shares to callers.
+type EncodingStorage struct {
+	inner IStorage
+	codec Codec
+}
+
+// WithEncoding wraps inner so shares are encoded with codec on write and
+// decoded on read.
+func WithEncoding(inner IStorage, codec Codec) IStorage {
+	return &EncodingStorage{inner: inner, codec: codec}
+}
+
+func (es *EncodingStorage) SetShare(index byte, share []byte) error {
+	return es.inner.SetShare(index, es.codec.Encode(share))
+}
+
+func (es *EncodingStorage) GetShare(index byte) ([]byte, error) {
+	data, err := es.inner.GetShare(index)
+	if err != nil {
+		return nil, err
+	}
+	share, err := es.codec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("shamir: decode share %d: %w", index, err)
+	}
+	return share, nil
+}
+
+func (es *EncodingStorage) ListShares() ([]byte, error) {
+	return es.inner.ListShares()
+}
+
+func (es *EncodingStorage) DeleteShare(index byte) error {
+	return es.inner.DeleteShare(index)
+}
+
+func (es *EncodingStorage) BatchSet(shares map[byte][]byte) error {
+	batch := make(map[byte][]byte, len(shares))
+	for idx, s := range shares {
+		batch[idx] = es.codec.Encode(s)
+	}
+	return es.inner.BatchSet(batch)
+}
@@ -0,0 +1,122 @@
+// storage/httpstore/client.go
+package httpstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+var (
+	// ErrConflict is returned when the server refuses to overwrite a share.
+	ErrConflict = errors.New("httpstore: share already exists")
+	// ErrUnauthorized is returned when the server rejects the bearer token.
+	ErrUnauthorized = errors.New("httpstore: unauthorized")
+)
+
+var _ storage.IStorage = (*Client)(nil)
+
+// Client implements IStorage against a Server.
+type Client struct {
+	baseURL string
+	token   string
+	hc      *http.Client
+}
+
+// NewClient returns a Client for the server at baseURL. token may be empty;
+// hc defaults to http.DefaultClient.
+func NewClient(baseURL, token string, hc *http.Client) *Client {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), token: token, hc: hc}
+}
+
+// do sends a request and maps error status codes onto errors.
+func (c *Client) do(method, path string, body []byte) ([]byte, error) {
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, rd)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("httpstore: %w", storage.ErrNotFound)
+	case resp.StatusCode == http.StatusConflict:
+		return nil, ErrConflict
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("httpstore: %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+func (c *Client) SetShare(index byte, share []byte) error {
+	if share == nil {
+		share = []byte{}
+	}
+	_, err := c.do(http.MethodPut, fmt.Sprintf("/shares/%d", index), share)
+	return err
+}
+
+func (c *Client) GetShare(index byte) ([]byte, error) {
+	return c.do(http.MethodGet, fmt.Sprintf("/shares/%d", index), nil)
+}
+
+func (c *Client) ListShares() ([]byte, error) {
+	data, err := c.do(http.MethodGet, "/shares", nil)
+	if err != nil {
+		return nil, err
+	}
+	var nums []int
+	if err := json.Unmarshal(data, &nums); err != nil {
+		return nil, fmt.Errorf("httpstore: decode list: %w", err)
+	}
+	indices := make([]byte, 0, len(nums))
+	for _, n := range nums {
+		if n < 0 || n > 255 {
+			return nil, fmt.Errorf("httpstore: invalid index %d in list", n)
+		}
+		indices = append(indices, byte(n))
+	}
+	return indices, nil
+}
+
+func (c *Client) DeleteShare(index byte) error {
+	_, err := c.do(http.MethodDelete, fmt.Sprintf("/shares/%d", index), nil)
+	return err
+}
+
+func (c *Client) BatchSet(shares map[byte][]byte) error {
+	for idx, s := range shares {
+		if err := c.SetShare(idx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// storage/httpstore/conformance_test.go
+package httpstore
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+func TestClientConformance(t *testing.T) {
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		srv := httptest.NewServer(NewServer(drivers.NewMemoryStorage(), ServerOptions{Token: "secret"}))
+		t.Cleanup(srv.Close)
+		return NewClient(srv.URL, "secret", srv.Client())
+	})
+}
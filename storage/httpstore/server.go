@@ -0,0 +1,167 @@
+// storage/httpstore/server.go
+package httpstore
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+// maxShareBody bounds the size of a PUT body.
+const maxShareBody = 1 << 20
+
+// ServerOptions configures a Server.
+type ServerOptions struct {
+	Token           string // if set, requests must carry "Authorization: Bearer <Token>"
+	RejectOverwrite bool   // if set, PUT on an existing index returns 409 Conflict
+
+	// ErrorLog receives the details of storage errors, which clients only
+	// see as a generic 500. If nil, the log package's standard logger is used.
+	ErrorLog *log.Logger
+}
+
+// Server exposes an IStorage over HTTP:
+//
+//	GET    /shares          list indices (JSON array of numbers)
+//	GET    /shares/{index}  fetch a share (application/octet-stream)
+//	PUT    /shares/{index}  store a share
+//	DELETE /shares/{index}  delete a share
+//
+// With RejectOverwrite, PUTs are serialized so two concurrent PUTs to the
+// same index can't both succeed. This only holds for writes that go through
+// the Server; anything else writing to the store directly can still race.
+type Server struct {
+	store storage.IStorage
+	opts  ServerOptions
+	mux   *http.ServeMux
+	putMu sync.Mutex // held across the existence check and write of a PUT
+}
+
+// NewServer returns a Server backed by store.
+func NewServer(store storage.IStorage, opts ServerOptions) *Server {
+	s := &Server{store: store, opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /shares", s.handleList)
+	s.mux.HandleFunc("GET /shares/{index}", s.handleGet)
+	s.mux.HandleFunc("PUT /shares/{index}", s.handlePut)
+	s.mux.HandleFunc("DELETE /shares/{index}", s.handleDelete)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.opts.Token != "" {
+		want := "Bearer " + s.opts.Token
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(want)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	indices, err := s.store.ListShares()
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	// []byte would marshal as base64, so send plain numbers
+	out := make([]int, len(indices))
+	for i, idx := range indices {
+		out[i] = int(idx)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	idx, ok := parseIndex(w, r)
+	if !ok {
+		return
+	}
+	share, err := s.store.GetShare(idx)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(share)
+}
+
+func (s *Server) handlePut(w http.ResponseWriter, r *http.Request) {
+	idx, ok := parseIndex(w, r)
+	if !ok {
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxShareBody+1))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxShareBody {
+		http.Error(w, "share too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if s.opts.RejectOverwrite {
+		s.putMu.Lock()
+		defer s.putMu.Unlock()
+		_, err := s.store.GetShare(idx)
+		if err == nil {
+			http.Error(w, "share already exists", http.StatusConflict)
+			return
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.writeError(w, err)
+			return
+		}
+	}
+	if err := s.store.SetShare(idx, body); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	idx, ok := parseIndex(w, r)
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteShare(idx); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseIndex reads the {index} path value, writing 400 if it isn't a byte.
+func parseIndex(w http.ResponseWriter, r *http.Request) (byte, bool) {
+	n, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil || n < 0 || n > 255 {
+		http.Error(w, "invalid share index", http.StatusBadRequest)
+		return 0, false
+	}
+	return byte(n), true
+}
+
+// writeError maps storage errors onto status codes. Other errors are logged
+// rather than sent, since they may reveal paths or backend details.
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, "share not found", http.StatusNotFound)
+		return
+	}
+	logger := s.opts.ErrorLog
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Printf("httpstore: %v", err)
+	http.Error(w, "internal storage error", http.StatusInternalServerError)
+}
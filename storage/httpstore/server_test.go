@@ -0,0 +1,63 @@
+// storage/httpstore/server_test.go
+package httpstore
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+// leakyStorage fails every write with an error naming an internal path.
+type leakyStorage struct{ *drivers.MemoryStorage }
+
+func (leakyStorage) SetShare(byte, []byte) error {
+	return errors.New("open /var/lib/shares/share_1.dat: permission denied")
+}
+
+func TestServerRejectOverwriteConcurrent(t *testing.T) {
+	srv := NewServer(drivers.NewMemoryStorage(), ServerOptions{RejectOverwrite: true})
+	const writers = 32
+	codes := make(chan int, writers)
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/shares/1", bytes.NewReader([]byte{byte(i)})))
+			codes <- rec.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+	counts := map[int]int{}
+	for code := range codes {
+		counts[code]++
+	}
+	if counts[http.StatusNoContent] != 1 || counts[http.StatusConflict] != writers-1 {
+		t.Fatalf("status counts = %v, want one 204 and %d 409s", counts, writers-1)
+	}
+}
+
+func TestServerHidesStorageErrors(t *testing.T) {
+	var logged bytes.Buffer
+	srv := NewServer(leakyStorage{drivers.NewMemoryStorage()}, ServerOptions{ErrorLog: log.New(&logged, "", 0)})
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/shares/1", bytes.NewReader([]byte("share"))))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "/var/lib") {
+		t.Fatalf("response leaks the backend error: %q", rec.Body.String())
+	}
+	if !strings.Contains(logged.String(), "/var/lib/shares/share_1.dat") {
+		t.Fatalf("backend error not logged: %q", logged.String())
+	}
+}
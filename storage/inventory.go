@@ -0,0 +1,55 @@
+// storage/inventory.go
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oarkflow/shamir"
+)
+
+// ShareInventory describes one stored share; see InventoryStorage.
+type ShareInventory struct {
+	Index     byte // the storage index the share is kept under
+	Length    int  // stored size in bytes; 0 if it couldn't be read
+	CRCValid  bool // the share parsed and its CRC or SHA-256 tag verified
+	Threshold byte
+	Total     byte
+	Err       error // why the share couldn't be read or verified
+}
+
+// InventoryStorage reads and parses every share in st and describes each
+// one, in ascending index order: the storage analogue of
+// shamir.InspectShares. Shares that can't be read or don't verify are
+// reported with Err set rather than aborting the inventory, as is a share
+// kept under an index other than its own. Only a failure to list st is
+// returned as an error.
+func InventoryStorage(st IStorage) ([]ShareInventory, error) {
+	indices, err := st.ListShares()
+	if err != nil {
+		return nil, fmt.Errorf("shamir: list shares: %w", err)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	out := make([]ShareInventory, len(indices))
+	for i, idx := range indices {
+		inv := &out[i]
+		inv.Index = idx
+		s, err := st.GetShare(idx)
+		if err != nil {
+			inv.Err = err
+			continue
+		}
+		inv.Length = len(s)
+		m := shamir.InspectShares([][]byte{s})[0]
+		inv.Threshold, inv.Total = m.Threshold, m.Total
+		switch {
+		case m.Err != nil:
+			inv.Err = m.Err
+		case m.Index != idx:
+			inv.Err = fmt.Errorf("shamir: stored under index %d but holds share %d", idx, m.Index)
+		default:
+			inv.CRCValid = true
+		}
+	}
+	return out, nil
+}
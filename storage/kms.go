@@ -0,0 +1,95 @@
+// storage/kms.go
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// KMSClient is the minimal envelope-encryption surface needed from a cloud KMS
+// (AWS KMS, Google Cloud KMS, ...).
+type KMSClient interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// KMSWrappedStorage encrypts shares with a KMS before they reach the inner storage.
+type KMSWrappedStorage struct {
+	inner ContextStorage
+	kms   KMSClient
+}
+
+// NewKMSWrappedStorage wraps inner so it only ever holds KMS ciphertext.
+// The returned storage also implements ContextStorage, passing the caller's
+// context to both the KMS and the inner storage; the plain IStorage methods
+// use context.Background().
+func NewKMSWrappedStorage(inner IStorage, kms KMSClient) *KMSWrappedStorage {
+	return &KMSWrappedStorage{inner: AsContextStorage(inner), kms: kms}
+}
+
+// SetShareContext encrypts the share and writes the ciphertext to the inner
+// storage.
+func (ks *KMSWrappedStorage) SetShareContext(ctx context.Context, index byte, share []byte) error {
+	ct, err := ks.kms.Encrypt(ctx, share)
+	if err != nil {
+		return fmt.Errorf("shamir: kms encrypt share %d: %w", index, err)
+	}
+	return ks.inner.SetShareContext(ctx, index, ct)
+}
+
+// GetShareContext reads the ciphertext from the inner storage and decrypts it.
+func (ks *KMSWrappedStorage) GetShareContext(ctx context.Context, index byte) ([]byte, error) {
+	ct, err := ks.inner.GetShareContext(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	pt, err := ks.kms.Decrypt(ctx, ct)
+	if err != nil {
+		return nil, fmt.Errorf("shamir: kms decrypt share %d: %w", index, err)
+	}
+	return pt, nil
+}
+
+// ListSharesContext lists the indices held by the inner storage.
+func (ks *KMSWrappedStorage) ListSharesContext(ctx context.Context) ([]byte, error) {
+	return ks.inner.ListSharesContext(ctx)
+}
+
+// DeleteShareContext deletes the share from the inner storage.
+func (ks *KMSWrappedStorage) DeleteShareContext(ctx context.Context, index byte) error {
+	return ks.inner.DeleteShareContext(ctx, index)
+}
+
+// BatchSetContext encrypts every share before handing the batch to the inner
+// storage.
+func (ks *KMSWrappedStorage) BatchSetContext(ctx context.Context, shares map[byte][]byte) error {
+	batch := make(map[byte][]byte, len(shares))
+	for idx, s := range shares {
+		ct, err := ks.kms.Encrypt(ctx, s)
+		if err != nil {
+			return fmt.Errorf("shamir: kms encrypt share %d: %w", idx, err)
+		}
+		batch[idx] = ct
+	}
+	return ks.inner.BatchSetContext(ctx, batch)
+}
+
+func (ks *KMSWrappedStorage) SetShare(index byte, share []byte) error {
+	return ks.SetShareContext(context.Background(), index, share)
+}
+
+func (ks *KMSWrappedStorage) GetShare(index byte) ([]byte, error) {
+	return ks.GetShareContext(context.Background(), index)
+}
+
+func (ks *KMSWrappedStorage) ListShares() ([]byte, error) {
+	return ks.ListSharesContext(context.Background())
+}
+
+func (ks *KMSWrappedStorage) DeleteShare(index byte) error {
+	return ks.DeleteShareContext(context.Background(), index)
+}
+
+func (ks *KMSWrappedStorage) BatchSet(shares map[byte][]byte) error {
+	return ks.BatchSetContext(context.Background(), shares)
+}
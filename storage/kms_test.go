@@ -0,0 +1,90 @@
+// storage/kms_test.go
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+type ctxKey struct{}
+
+// xorKMS is a toy KMS that fails once its context is done and records the
+// value stored under ctxKey for every call.
+type xorKMS struct {
+	seen []any
+}
+
+func (k *xorKMS) crypt(ctx context.Context, in []byte) ([]byte, error) {
+	k.seen = append(k.seen, ctx.Value(ctxKey{}))
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	for i, b := range in {
+		out[i] = b ^ 0x5a
+	}
+	return out, nil
+}
+
+func (k *xorKMS) Encrypt(ctx context.Context, pt []byte) ([]byte, error) { return k.crypt(ctx, pt) }
+func (k *xorKMS) Decrypt(ctx context.Context, ct []byte) ([]byte, error) { return k.crypt(ctx, ct) }
+
+func TestKMSWrappedStorageContext(t *testing.T) {
+	share := []byte("share")
+	tests := []struct {
+		name string
+		op   func(ctx context.Context, ks *storage.KMSWrappedStorage) error
+	}{
+		{"SetShareContext", func(ctx context.Context, ks *storage.KMSWrappedStorage) error {
+			return ks.SetShareContext(ctx, 1, share)
+		}},
+		{"GetShareContext", func(ctx context.Context, ks *storage.KMSWrappedStorage) error {
+			got, err := ks.GetShareContext(ctx, 2)
+			if err == nil && !bytes.Equal(got, share) {
+				t.Errorf("GetShareContext = %q, want %q", got, share)
+			}
+			return err
+		}},
+		{"BatchSetContext", func(ctx context.Context, ks *storage.KMSWrappedStorage) error {
+			return ks.BatchSetContext(ctx, map[byte][]byte{3: share, 4: share})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := drivers.NewMemoryStorage()
+			kms := &xorKMS{}
+			ks := storage.NewKMSWrappedStorage(inner, kms)
+			if err := ks.SetShare(2, share); err != nil {
+				t.Fatal(err)
+			}
+			if stored, _ := inner.GetShare(2); bytes.Equal(stored, share) {
+				t.Fatal("inner storage holds the plaintext share")
+			}
+
+			kms.seen = nil
+			ctx := context.WithValue(context.Background(), ctxKey{}, "caller")
+			if err := tt.op(ctx, ks); err != nil {
+				t.Fatal(err)
+			}
+			if len(kms.seen) == 0 {
+				t.Fatal("KMS was not called")
+			}
+			for _, v := range kms.seen {
+				if v != "caller" {
+					t.Fatalf("KMS saw context value %v, want the caller's context", v)
+				}
+			}
+
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			if err := tt.op(cancelled, ks); !errors.Is(err, context.Canceled) {
+				t.Fatalf("error with cancelled context = %v, want context.Canceled", err)
+			}
+		})
+	}
+}
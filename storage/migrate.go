@@ -0,0 +1,88 @@
+// storage/migrate.go
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MigrateOptions controls MigrateStorage.
+type MigrateOptions struct {
+	Verify       bool // read each copy back from dst and compare
+	DeleteSource bool // delete from src once every copy has verified; implies Verify
+	DryRun       bool // only report what would be copied
+}
+
+// MigrateReport lists what happened to each source index.
+type MigrateReport struct {
+	Copied   []byte // written to dst
+	Verified []byte // read back from dst and matched
+	Skipped  []byte // not written (dry run)
+	Failed   []byte // read, write or verification failed
+	Deleted  []byte // removed from src after a successful migration
+}
+
+// MigrateStorage copies every share from src to dst. Source shares are only
+// deleted when DeleteSource is set and every copy verified, so a failure
+// part-way through always leaves src intact. Per-index failures are joined
+// into the returned error.
+func MigrateStorage(src, dst IStorage, opts MigrateOptions) (MigrateReport, error) {
+	var report MigrateReport
+	indices, err := src.ListShares()
+	if err != nil {
+		return report, fmt.Errorf("shamir: list source: %w", err)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	if opts.DryRun {
+		report.Skipped = append(report.Skipped, indices...)
+		return report, nil
+	}
+	verify := opts.Verify || opts.DeleteSource
+
+	var errs []error
+	for _, idx := range indices {
+		share, err := src.GetShare(idx)
+		if err != nil {
+			report.Failed = append(report.Failed, idx)
+			errs = append(errs, fmt.Errorf("shamir: read share %d: %w", idx, err))
+			continue
+		}
+		if err := dst.SetShare(idx, share); err != nil {
+			report.Failed = append(report.Failed, idx)
+			errs = append(errs, fmt.Errorf("shamir: write share %d: %w", idx, err))
+			continue
+		}
+		report.Copied = append(report.Copied, idx)
+		if !verify {
+			continue
+		}
+		got, err := dst.GetShare(idx)
+		if err != nil {
+			report.Failed = append(report.Failed, idx)
+			errs = append(errs, fmt.Errorf("shamir: read back share %d: %w", idx, err))
+			continue
+		}
+		if !bytes.Equal(got, share) {
+			report.Failed = append(report.Failed, idx)
+			errs = append(errs, fmt.Errorf("shamir: share %d differs after copy", idx))
+			continue
+		}
+		report.Verified = append(report.Verified, idx)
+	}
+	if len(errs) > 0 {
+		return report, errors.Join(errs...)
+	}
+
+	if opts.DeleteSource {
+		for _, idx := range report.Verified {
+			if err := src.DeleteShare(idx); err != nil {
+				errs = append(errs, fmt.Errorf("shamir: delete source share %d: %w", idx, err))
+				continue
+			}
+			report.Deleted = append(report.Deleted, idx)
+		}
+	}
+	return report, errors.Join(errs...)
+}
@@ -0,0 +1,119 @@
+// storage/migrate_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+var errWrite = errors.New("write refused")
+
+// faultyDest refuses writes of failIndex and, if corrupt is set, stores
+// every other share with its last byte flipped.
+type faultyDest struct {
+	*drivers.MemoryStorage
+	failIndex byte
+	corrupt   bool
+}
+
+func (f *faultyDest) SetShare(index byte, share []byte) error {
+	if index == f.failIndex {
+		return errWrite
+	}
+	if f.corrupt {
+		share = bytes.Clone(share)
+		share[len(share)-1] ^= 1
+	}
+	return f.MemoryStorage.SetShare(index, share)
+}
+
+func TestMigrateStorage(t *testing.T) {
+	all := []byte{1, 2, 3, 4}
+	tests := []struct {
+		name      string
+		opts      storage.MigrateOptions
+		failIndex byte
+		corrupt   bool
+		wantErr   bool
+		errIs     error // also wrapped by the error, if set
+		want      storage.MigrateReport
+		srcLeft   []byte
+		dstHas    []byte
+	}{
+		{
+			name:    "copy",
+			want:    storage.MigrateReport{Copied: all},
+			srcLeft: all, dstHas: all,
+		},
+		{
+			name:    "verify",
+			opts:    storage.MigrateOptions{Verify: true},
+			want:    storage.MigrateReport{Copied: all, Verified: all},
+			srcLeft: all, dstHas: all,
+		},
+		{
+			name:    "move",
+			opts:    storage.MigrateOptions{DeleteSource: true},
+			want:    storage.MigrateReport{Copied: all, Verified: all, Deleted: all},
+			srcLeft: nil, dstHas: all,
+		},
+		{
+			name:      "destination fails mid-migration",
+			opts:      storage.MigrateOptions{DeleteSource: true},
+			failIndex: 3,
+			wantErr:   true,
+			errIs:     errWrite,
+			want:      storage.MigrateReport{Copied: []byte{1, 2, 4}, Verified: []byte{1, 2, 4}, Failed: []byte{3}},
+			srcLeft:   all, dstHas: []byte{1, 2, 4},
+		},
+		{
+			name:    "copies don't verify",
+			opts:    storage.MigrateOptions{DeleteSource: true},
+			corrupt: true,
+			wantErr: true,
+			want:    storage.MigrateReport{Copied: all, Failed: all},
+			srcLeft: all, dstHas: all,
+		},
+		{
+			name:    "dry run",
+			opts:    storage.MigrateOptions{DryRun: true, DeleteSource: true},
+			want:    storage.MigrateReport{Skipped: all},
+			srcLeft: all, dstHas: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := drivers.NewMemoryStorage()
+			for _, idx := range all {
+				_ = src.SetShare(idx, []byte{idx, 0xaa, idx})
+			}
+			dst := &faultyDest{MemoryStorage: drivers.NewMemoryStorage(), failIndex: tt.failIndex, corrupt: tt.corrupt}
+
+			report, err := storage.MigrateStorage(src, dst, tt.opts)
+			if (err != nil) != tt.wantErr || (tt.errIs != nil && !errors.Is(err, tt.errIs)) {
+				t.Fatalf("MigrateStorage error = %v, want error: %v (%v)", err, tt.wantErr, tt.errIs)
+			}
+			if !reflect.DeepEqual(report, tt.want) {
+				t.Fatalf("report = %+v, want %+v", report, tt.want)
+			}
+			for _, idx := range all {
+				got, err := src.GetShare(idx)
+				left := bytes.IndexByte(tt.srcLeft, idx) >= 0
+				if left && (err != nil || !bytes.Equal(got, []byte{idx, 0xaa, idx})) {
+					t.Fatalf("source share %d = %x, %v; want it untouched", idx, got, err)
+				}
+				if !left && !errors.Is(err, storage.ErrNotFound) {
+					t.Fatalf("source share %d still present", idx)
+				}
+			}
+			if got, _ := dst.ListSharesSorted(); !bytes.Equal(got, tt.dstHas) {
+				t.Fatalf("destination holds %v, want %v", got, tt.dstHas)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// storage/mirror.go
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/oarkflow/shamir"
+)
+
+// ErrWriteQuorum is returned when fewer backends than the write quorum
+// accepted a write.
+var ErrWriteQuorum = errors.New("shamir: write quorum not reached")
+
+var _ IStorage = (*MirrorStorage)(nil)
+
+// MirrorStorage keeps a copy of every share on each of several backends,
+// for indices valuable enough to survive the loss of a physical store.
+// Writes go to every backend and succeed once WriteQuorum of them accept;
+// reads are served by the first backend that answers.
+//
+// A share stored in JSON envelope form with a ReplicationHint of h is only
+// written to the first h backends (all of them if h is zero or exceeds the
+// backend count), and the write quorum is capped at h. Once the write
+// succeeds, the index is deleted from the backends beyond the hint so they
+// can't serve a stale copy; that cleanup is best-effort, and a backend that
+// fails it keeps its old copy until the next write.
+type MirrorStorage struct {
+	backends []IStorage
+
+	// WriteQuorum is the number of backends that must accept a write for it
+	// to succeed. Zero or a value above the backend count means all of them.
+	// Backends that rejected a write are not rolled back and may hold a
+	// stale copy until the next successful write.
+	WriteQuorum int
+}
+
+// NewMirrorStorage mirrors shares across backends, which are read in the
+// given order.
+func NewMirrorStorage(backends ...IStorage) *MirrorStorage {
+	return &MirrorStorage{backends: backends}
+}
+
+func (m *MirrorStorage) quorum() int {
+	if m.WriteQuorum <= 0 || m.WriteQuorum > len(m.backends) {
+		return len(m.backends)
+	}
+	return m.WriteQuorum
+}
+
+// replicas returns how many backends a share should be written to.
+func (m *MirrorStorage) replicas(share []byte) int {
+	if !bytes.HasPrefix(bytes.TrimSpace(share), []byte("{")) {
+		return len(m.backends)
+	}
+	h, err := shamir.ReplicationHint(share)
+	if err != nil || h == 0 || int(h) > len(m.backends) {
+		return len(m.backends)
+	}
+	return int(h)
+}
+
+// each runs op against the first n backends concurrently and returns the
+// number of successes and the failures, labelled by backend position.
+func (m *MirrorStorage) each(n int, op func(IStorage) error) (int, []error) {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i, b := range m.backends[:n] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := op(b); err != nil {
+				errs[i] = fmt.Errorf("mirror %d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	ok := 0
+	var failed []error
+	for _, err := range errs {
+		if err == nil {
+			ok++
+		} else {
+			failed = append(failed, err)
+		}
+	}
+	return ok, failed
+}
+
+// write applies op to the first n backends and enforces the write quorum.
+func (m *MirrorStorage) write(n int, op func(IStorage) error) error {
+	if len(m.backends) == 0 {
+		return fmt.Errorf("%w: no backends", ErrWriteQuorum)
+	}
+	ok, failed := m.each(n, op)
+	if q := min(m.quorum(), n); ok < q {
+		return fmt.Errorf("%w: %d of %d backends accepted, need %d: %w", ErrWriteQuorum, ok, n, q, errors.Join(failed...))
+	}
+	return nil
+}
+
+func (m *MirrorStorage) SetShare(index byte, share []byte) error {
+	n := m.replicas(share)
+	if err := m.write(n, func(b IStorage) error { return b.SetShare(index, share) }); err != nil {
+		return err
+	}
+	m.prune(n, index)
+	return nil
+}
+
+// prune deletes index from every backend past the first n, ignoring
+// failures.
+func (m *MirrorStorage) prune(n int, index byte) {
+	var wg sync.WaitGroup
+	for _, b := range m.backends[n:] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = b.DeleteShare(index)
+		}()
+	}
+	wg.Wait()
+}
+
+// BatchSet writes the batch to every backend, except that shares carrying a
+// replication hint below the backend count are written individually.
+func (m *MirrorStorage) BatchSet(shares map[byte][]byte) error {
+	full := make(map[byte][]byte, len(shares))
+	var hinted []byte
+	for idx, s := range shares {
+		if m.replicas(s) < len(m.backends) {
+			hinted = append(hinted, idx)
+		} else {
+			full[idx] = s
+		}
+	}
+	if len(full) > 0 || len(hinted) == 0 {
+		if err := m.write(len(m.backends), func(b IStorage) error { return b.BatchSet(full) }); err != nil {
+			return err
+		}
+	}
+	sort.Slice(hinted, func(i, j int) bool { return hinted[i] < hinted[j] })
+	for _, idx := range hinted {
+		if err := m.SetShare(idx, shares[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetShare returns the share from the first backend that has it.
+func (m *MirrorStorage) GetShare(index byte) ([]byte, error) {
+	var errs []error
+	for i, b := range m.backends {
+		s, err := b.GetShare(index)
+		if err == nil {
+			return s, nil
+		}
+		errs = append(errs, fmt.Errorf("mirror %d: %w", i, err))
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("mirror: %w", ErrNotFound)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// ListShares returns the union of the indices held by the backends that
+// could be listed. It fails only if no backend could be listed.
+func (m *MirrorStorage) ListShares() ([]byte, error) {
+	var seen [256]bool
+	var errs []error
+	listed := false
+	for i, b := range m.backends {
+		indices, err := b.ListShares()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mirror %d: %w", i, err))
+			continue
+		}
+		listed = true
+		for _, idx := range indices {
+			seen[idx] = true
+		}
+	}
+	if !listed && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	var out []byte
+	for idx, ok := range seen {
+		if ok {
+			out = append(out, byte(idx))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+// DeleteShare deletes the share from every backend. Backends that don't
+// hold it count as having deleted it; the share must have been found on at
+// least one backend.
+func (m *MirrorStorage) DeleteShare(index byte) error {
+	var mu sync.Mutex
+	found := false
+	err := m.write(len(m.backends), func(b IStorage) error {
+		err := b.DeleteShare(index)
+		if err == nil {
+			mu.Lock()
+			found = true
+			mu.Unlock()
+		}
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("mirror: %w", ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// storage/mirror_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+var errDown = errors.New("backend down")
+
+// downStorage fails every operation while down is set.
+type downStorage struct {
+	*drivers.MemoryStorage
+	down bool
+}
+
+func (d *downStorage) SetShare(index byte, share []byte) error {
+	if d.down {
+		return errDown
+	}
+	return d.MemoryStorage.SetShare(index, share)
+}
+
+func (d *downStorage) GetShare(index byte) ([]byte, error) {
+	if d.down {
+		return nil, errDown
+	}
+	return d.MemoryStorage.GetShare(index)
+}
+
+func (d *downStorage) ListShares() ([]byte, error) {
+	if d.down {
+		return nil, errDown
+	}
+	return d.MemoryStorage.ListShares()
+}
+
+func (d *downStorage) DeleteShare(index byte) error {
+	if d.down {
+		return errDown
+	}
+	return d.MemoryStorage.DeleteShare(index)
+}
+
+func (d *downStorage) BatchSet(shares map[byte][]byte) error {
+	if d.down {
+		return errDown
+	}
+	return d.MemoryStorage.BatchSet(shares)
+}
+
+func newMirror(n int) (*storage.MirrorStorage, []*downStorage) {
+	backends := make([]*downStorage, n)
+	st := make([]storage.IStorage, n)
+	for i := range backends {
+		backends[i] = &downStorage{MemoryStorage: drivers.NewMemoryStorage()}
+		st[i] = backends[i]
+	}
+	return storage.NewMirrorStorage(st...), backends
+}
+
+func TestMirrorStorageReplicationHint(t *testing.T) {
+	shares, err := shamir.SplitWithReplicationHints([]byte("hinted"), 2, []byte{3, 1, 0, 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		share []byte
+		want  int // backends holding the share
+	}{
+		{"hint 3", shares[0], 3},
+		{"hint 1", shares[1], 1},
+		{"no hint", shares[2], 5},
+		{"hint above backend count", shares[3], 5},
+		{"binary share", []byte("SHAM binary"), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, backends := newMirror(5)
+			if err := m.SetShare(1, tt.share); err != nil {
+				t.Fatal(err)
+			}
+			held := 0
+			for _, b := range backends {
+				if _, err := b.GetShare(1); err == nil {
+					held++
+				}
+			}
+			if held != tt.want {
+				t.Fatalf("share held by %d backends, want %d", held, tt.want)
+			}
+		})
+	}
+}
+
+func TestMirrorStorageHintPrunesStaleCopies(t *testing.T) {
+	m, backends := newMirror(5)
+	if err := m.SetShare(1, []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	shares, err := shamir.SplitWithReplicationHints([]byte("hinted"), 2, []byte{2, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetShare(1, shares[0]); err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range backends[2:] {
+		if _, err := b.GetShare(1); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("backend %d beyond the hint still holds the share: %v", i+2, err)
+		}
+	}
+
+	// with both hinted backends down, the read must fail rather than fall
+	// through to a stale copy
+	backends[0].down, backends[1].down = true, true
+	if got, err := m.GetShare(1); err == nil {
+		t.Fatalf("GetShare served %q with the hinted backends down", got)
+	}
+	backends[0].down = false
+	got, err := m.GetShare(1)
+	if err != nil || !bytes.Equal(got, shares[0]) {
+		t.Fatalf("GetShare = %q, %v; want the hinted share", got, err)
+	}
+	indices, err := m.ListShares()
+	if err != nil || !bytes.Equal(indices, []byte{1}) {
+		t.Fatalf("ListShares = %v, %v", indices, err)
+	}
+}
+
+func TestMirrorStorageFailingBackend(t *testing.T) {
+	m, backends := newMirror(3)
+	if err := m.SetShare(1, []byte("mirrored")); err != nil {
+		t.Fatal(err)
+	}
+	backends[0].down = true
+
+	got, err := m.GetShare(1)
+	if err != nil || !bytes.Equal(got, []byte("mirrored")) {
+		t.Fatalf("GetShare with backend 0 down = %q, %v", got, err)
+	}
+	if indices, err := m.ListShares(); err != nil || !bytes.Equal(indices, []byte{1}) {
+		t.Fatalf("ListShares with backend 0 down = %v, %v", indices, err)
+	}
+}
+
+func TestMirrorStorageWriteQuorum(t *testing.T) {
+	tests := []struct {
+		name    string
+		quorum  int
+		down    []int
+		wantErr bool
+	}{
+		{"all required, all up", 0, nil, false},
+		{"all required, one down", 0, []int{2}, true},
+		{"quorum 2, one down", 2, []int{0}, false},
+		{"quorum 2, two down", 2, []int{0, 1}, true},
+		{"quorum above count, one down", 7, []int{1}, true},
+		{"quorum 1, two down", 1, []int{0, 2}, false},
+	}
+	ops := []struct {
+		name string
+		run  func(m *storage.MirrorStorage) error
+	}{
+		{"SetShare", func(m *storage.MirrorStorage) error { return m.SetShare(2, []byte("new")) }},
+		{"BatchSet", func(m *storage.MirrorStorage) error {
+			return m.BatchSet(map[byte][]byte{2: []byte("new"), 3: []byte("new")})
+		}},
+		{"DeleteShare", func(m *storage.MirrorStorage) error { return m.DeleteShare(1) }},
+	}
+	for _, tt := range tests {
+		for _, op := range ops {
+			t.Run(tt.name+"/"+op.name, func(t *testing.T) {
+				m, backends := newMirror(3)
+				if err := m.SetShare(1, []byte("old")); err != nil {
+					t.Fatal(err)
+				}
+				m.WriteQuorum = tt.quorum
+				for _, i := range tt.down {
+					backends[i].down = true
+				}
+				err := op.run(m)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("error = %v, want error: %v", err, tt.wantErr)
+				}
+				if tt.wantErr && (!errors.Is(err, storage.ErrWriteQuorum) || !errors.Is(err, errDown)) {
+					t.Fatalf("error = %v, want ErrWriteQuorum wrapping the backend error", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// storage/multistorage_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+// delayedStorage takes delay for every BatchSet and then fails with err if
+// it is set.
+type delayedStorage struct {
+	*drivers.MemoryStorage
+	delay time.Duration
+	err   error
+}
+
+func (d *delayedStorage) BatchSet(shares map[byte][]byte) error {
+	time.Sleep(d.delay)
+	if d.err != nil {
+		return d.err
+	}
+	return d.MemoryStorage.BatchSet(shares)
+}
+
+func TestMultiStorageBatchSetConcurrent(t *testing.T) {
+	const fastDelay, slowDelay = 100 * time.Millisecond, 150 * time.Millisecond
+	fast := &delayedStorage{MemoryStorage: drivers.NewMemoryStorage(), delay: fastDelay}
+	slow := &delayedStorage{MemoryStorage: drivers.NewMemoryStorage(), delay: slowDelay}
+	ms := storage.NewMultiStorage()
+	if err := ms.AssignAll(fast, []byte{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ms.AssignAll(slow, []byte{3, 4, 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	err := ms.BatchSet(map[byte][]byte{1: {1}, 2: {2}, 3: {3}, 4: {4}, 5: {5}})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// each backend gets one BatchSet, and the two run side by side
+	if elapsed < slowDelay || elapsed >= slowDelay+fastDelay*3/4 {
+		t.Fatalf("BatchSet took %v, want about %v (sequential would be %v)", elapsed, slowDelay, slowDelay+fastDelay)
+	}
+	for idx, st := range map[byte]storage.IStorage{1: fast, 2: fast, 3: slow, 4: slow, 5: slow} {
+		if got, err := st.GetShare(idx); err != nil || got[0] != idx {
+			t.Fatalf("share %d = %v, %v", idx, got, err)
+		}
+	}
+}
+
+func TestMultiStorageBatchSetErrors(t *testing.T) {
+	errA, errB := errors.New("backend a failed"), errors.New("backend b failed")
+	a := &delayedStorage{MemoryStorage: drivers.NewMemoryStorage(), err: errA}
+	b := &delayedStorage{MemoryStorage: drivers.NewMemoryStorage(), err: errB}
+	ok := drivers.NewMemoryStorage()
+	ms := storage.NewMultiStorage()
+	ms.AssignStorage(1, a)
+	ms.AssignStorage(2, b)
+	ms.AssignStorage(3, ok)
+
+	err := ms.BatchSet(map[byte][]byte{1: {1}, 2: {2}, 3: {3}})
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("BatchSet error = %v, want both backend errors joined", err)
+	}
+	if _, err := ok.GetShare(3); err != nil {
+		t.Fatalf("healthy backend not written: %v", err)
+	}
+
+	// an unassigned index fails the whole batch before anything is written
+	fresh := drivers.NewMemoryStorage()
+	ms.AssignStorage(4, fresh)
+	if err := ms.BatchSet(map[byte][]byte{4: {4}, 9: {9}}); !errors.Is(err, storage.ErrNoBackend) {
+		t.Fatalf("BatchSet with unassigned index: %v, want ErrNoBackend", err)
+	}
+	if _, err := fresh.GetShare(4); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("share 4 written despite the unassigned index: %v", err)
+	}
+}
+
+func TestMultiStorageQuorumPlan(t *testing.T) {
+	ms := storage.NewMultiStorage()
+	mem := drivers.NewMemoryStorage()
+	for idx, cost := range map[byte]int{1: 5, 2: 1, 3: 3, 4: 1, 5: 3} {
+		ms.AssignStorageWithCost(idx, mem, cost)
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		want      []byte
+		wantErr   bool
+	}{
+		{"cheapest single", 1, []byte{2}, false},
+		{"tie broken by index", 2, []byte{2, 4}, false},
+		{"next cost tier", 3, []byte{2, 4, 3}, false},
+		{"tier tie broken by index", 4, []byte{2, 4, 3, 5}, false},
+		{"every backend", 5, []byte{2, 4, 3, 5, 1}, false},
+		{"unreachable", 6, nil, true},
+		{"zero threshold", 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ms.QuorumPlan(tt.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QuorumPlan(%d) error = %v, want error: %v", tt.threshold, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("QuorumPlan(%d) = %v, want %v", tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+// MultiStorage.DeleteAll keeps the assignments, so ListShares still reports
+// them; the backends must be empty of routed shares and keep anything else.
+func TestMultiStorageDeleteAll(t *testing.T) {
+	a, b := drivers.NewMemoryStorage(), drivers.NewMemoryStorage()
+	ms := storage.NewMultiStorage()
+	ms.AssignStorage(1, a)
+	ms.AssignStorage(2, b)
+	ms.AssignStorage(3, b)
+	for _, idx := range []byte{1, 2} {
+		if err := ms.SetShare(idx, []byte{idx}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.SetShare(9, []byte("unrouted")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := storage.DeleteAll(ms); err != nil {
+		t.Fatal(err)
+	}
+	for _, idx := range []byte{1, 2, 3} {
+		if _, err := ms.GetShare(idx); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("GetShare(%d) after DeleteAll = %v, want ErrNotFound", idx, err)
+		}
+	}
+	if left, err := a.ListShares(); err != nil || len(left) != 0 {
+		t.Errorf("backend a holds %v, %v", left, err)
+	}
+	if left, err := b.ListShares(); err != nil || !bytes.Equal(left, []byte{9}) {
+		t.Errorf("backend b holds %v, %v, want only the unrouted [9]", left, err)
+	}
+}
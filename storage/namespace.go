@@ -0,0 +1,225 @@
+// storage/namespace.go
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// nsMagic starts every bundle written by a NamespacedStorage.
+const nsMagic = "SHNS"
+
+// MaxNamespaceLen is the longest namespace name, in bytes, a bundle can
+// record. SetShare rejects longer names.
+const MaxNamespaceLen = math.MaxUint16
+
+// namespacedMu is the lock shared by every view returned by Namespaced.
+var namespacedMu sync.Mutex
+
+var _ IStorage = (*NamespacedStorage)(nil)
+
+// Namespaces shares one inner storage among several secrets. The inner
+// storage only has one slot per index, so each slot holds a bundle of the
+// shares every namespace stored under that index; a view returned by
+// Namespace reads and writes only its own entry, and every namespace has the
+// full index space 1..255 to itself.
+//
+// Because slots hold bundles rather than shares, the inner storage must only
+// be accessed through views of a single Namespaces. Its views share one
+// lock, so concurrent writes from different namespaces are safe within one
+// process.
+type Namespaces struct {
+	inner IStorage
+	mu    sync.Mutex
+}
+
+// NewNamespaces returns the namespace factory owning inner.
+func NewNamespaces(inner IStorage) *Namespaces {
+	return &Namespaces{inner: inner}
+}
+
+// Namespace returns the view of the inner storage for namespace.
+func (n *Namespaces) Namespace(namespace string) IStorage {
+	return &NamespacedStorage{inner: n.inner, namespace: namespace, mu: &n.mu}
+}
+
+// Namespaced returns the view of inner for namespace without a Namespaces
+// factory. Every view it returns shares one process-wide lock, so views of
+// the same inner storage are safe to use concurrently, at the cost of also
+// serializing views of unrelated storages; use NewNamespaces to give a
+// storage its own lock. Don't mix views from Namespaced and from a
+// Namespaces over the same inner storage, since they lock separately.
+func Namespaced(inner IStorage, namespace string) IStorage {
+	return &NamespacedStorage{inner: inner, namespace: namespace, mu: &namespacedMu}
+}
+
+// NamespacedStorage is one secret's view of a storage shared through
+// Namespaces.
+type NamespacedStorage struct {
+	inner     IStorage
+	namespace string
+	mu        *sync.Mutex
+}
+
+// Namespace returns the namespace of the view.
+func (ns *NamespacedStorage) Namespace() string { return ns.namespace }
+
+// SetShare fails without writing if the namespace name is longer than
+// MaxNamespaceLen, or if the slot already holds the maximum number of
+// namespaces.
+func (ns *NamespacedStorage) SetShare(index byte, share []byte) error {
+	if len(ns.namespace) > MaxNamespaceLen {
+		return fmt.Errorf("shamir: namespace name is %d bytes, limit %d", len(ns.namespace), MaxNamespaceLen)
+	}
+	if uint64(len(share)) > math.MaxUint32 {
+		return fmt.Errorf("shamir: namespace %q: share of %d bytes is too large", ns.namespace, len(share))
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	b, err := ns.bundle(index)
+	if err != nil {
+		return err
+	}
+	if _, ok := b[ns.namespace]; !ok && len(b) >= math.MaxUint16 {
+		return fmt.Errorf("shamir: slot %d already holds %d namespaces", index, len(b))
+	}
+	b[ns.namespace] = share
+	return ns.inner.SetShare(index, encodeBundle(b))
+}
+
+func (ns *NamespacedStorage) GetShare(index byte) ([]byte, error) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	b, err := ns.bundle(index)
+	if err != nil {
+		return nil, err
+	}
+	share, ok := b[ns.namespace]
+	if !ok {
+		return nil, fmt.Errorf("namespace %q: %w", ns.namespace, ErrNotFound)
+	}
+	return share, nil
+}
+
+func (ns *NamespacedStorage) ListShares() ([]byte, error) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	indices, err := ns.inner.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	var out []byte
+	for _, idx := range indices {
+		b, err := ns.bundle(idx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := b[ns.namespace]; ok {
+			out = append(out, idx)
+		}
+	}
+	return out, nil
+}
+
+func (ns *NamespacedStorage) DeleteShare(index byte) error {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	b, err := ns.bundle(index)
+	if err != nil {
+		return err
+	}
+	if _, ok := b[ns.namespace]; !ok {
+		return fmt.Errorf("namespace %q: %w", ns.namespace, ErrNotFound)
+	}
+	delete(b, ns.namespace)
+	if len(b) == 0 {
+		return ns.inner.DeleteShare(index)
+	}
+	return ns.inner.SetShare(index, encodeBundle(b))
+}
+
+func (ns *NamespacedStorage) BatchSet(shares map[byte][]byte) error {
+	for _, idx := range SortedIndices(shares) {
+		if err := ns.SetShare(idx, shares[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundle reads the bundle at index; a missing slot is an empty bundle.
+// The caller must hold ns.mu.
+func (ns *NamespacedStorage) bundle(index byte) (map[string][]byte, error) {
+	data, err := ns.inner.GetShare(index)
+	if errors.Is(err, ErrNotFound) {
+		return make(map[string][]byte), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeBundle(data)
+	if err != nil {
+		return nil, fmt.Errorf("shamir: slot %d: %w", index, err)
+	}
+	return b, nil
+}
+
+// encodeBundle serializes a bundle as
+// magic(4) count(2) { nameLen(2) name shareLen(4) share }, sorted by name.
+// SetShare keeps every count and length within its field.
+func encodeBundle(b map[string][]byte) []byte {
+	names := make([]string, 0, len(b))
+	size := len(nsMagic) + 2
+	for name, share := range b {
+		names = append(names, name)
+		size += 2 + len(name) + 4 + len(share)
+	}
+	sort.Strings(names)
+	out := make([]byte, 0, size)
+	out = append(out, nsMagic...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(names)))
+	for _, name := range names {
+		out = binary.BigEndian.AppendUint16(out, uint16(len(name)))
+		out = append(out, name...)
+		out = binary.BigEndian.AppendUint32(out, uint32(len(b[name])))
+		out = append(out, b[name]...)
+	}
+	return out
+}
+
+// decodeBundle parses a bundle written by encodeBundle.
+func decodeBundle(data []byte) (map[string][]byte, error) {
+	errBad := errors.New("not a namespace bundle")
+	if len(data) < len(nsMagic)+2 || string(data[:len(nsMagic)]) != nsMagic {
+		return nil, errBad
+	}
+	count := int(binary.BigEndian.Uint16(data[len(nsMagic):]))
+	rest := data[len(nsMagic)+2:]
+	b := make(map[string][]byte, count)
+	for i := 0; i < count; i++ {
+		if len(rest) < 2 {
+			return nil, errBad
+		}
+		n := int(binary.BigEndian.Uint16(rest))
+		if len(rest) < 2+n+4 {
+			return nil, errBad
+		}
+		name := string(rest[2 : 2+n])
+		rest = rest[2+n:]
+		m := int(binary.BigEndian.Uint32(rest))
+		if len(rest) < 4+m {
+			return nil, errBad
+		}
+		b[name] = append([]byte(nil), rest[4:4+m]...)
+		rest = rest[4+m:]
+	}
+	if len(rest) != 0 {
+		return nil, errBad
+	}
+	return b, nil
+}
@@ -0,0 +1,117 @@
+// storage/namespace_test.go
+package storage_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+func TestNamespacedStorageConformance(t *testing.T) {
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		return storage.NewNamespaces(drivers.NewMemoryStorage()).Namespace("tenant")
+	})
+}
+
+// sliceStorage is an IStorage with value receivers and an uncomparable
+// underlying type, which a map keyed by IStorage can't hold.
+type sliceStorage []*drivers.MemoryStorage
+
+func (s sliceStorage) SetShare(i byte, b []byte) error       { return s[0].SetShare(i, b) }
+func (s sliceStorage) GetShare(i byte) ([]byte, error)       { return s[0].GetShare(i) }
+func (s sliceStorage) ListShares() ([]byte, error)           { return s[0].ListShares() }
+func (s sliceStorage) DeleteShare(i byte) error              { return s[0].DeleteShare(i) }
+func (s sliceStorage) BatchSet(shares map[byte][]byte) error { return s[0].BatchSet(shares) }
+
+func TestNamespacesIsolation(t *testing.T) {
+	inner := sliceStorage{drivers.NewMemoryStorage()}
+	ns := storage.NewNamespaces(inner)
+	names := []string{"a", "b", "c", "d"}
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			view := ns.Namespace(name)
+			for idx := byte(1); idx <= 5; idx++ {
+				if err := view.SetShare(idx, []byte(name+fmt.Sprint(idx))); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		view := ns.Namespace(name)
+		for idx := byte(1); idx <= 5; idx++ {
+			got, err := view.GetShare(idx)
+			if err != nil || !bytes.Equal(got, []byte(name+fmt.Sprint(idx))) {
+				t.Fatalf("%s/%d = %q, %v", name, idx, got, err)
+			}
+		}
+	}
+	if err := ns.Namespace("a").DeleteShare(1); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ns.Namespace("b").GetShare(1); err != nil || string(got) != "b1" {
+		t.Fatalf("deleting a/1 disturbed b/1: %q, %v", got, err)
+	}
+}
+
+func TestNamespacedTwoSecrets(t *testing.T) {
+	inner := drivers.NewMemoryStorage()
+	a, b := storage.Namespaced(inner, "alpha"), storage.Namespaced(inner, "beta")
+	for idx := byte(1); idx <= 3; idx++ {
+		if err := a.SetShare(idx, []byte{'a', idx}); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.SetShare(idx, []byte{'b', idx}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for idx := byte(1); idx <= 3; idx++ {
+		if got, err := a.GetShare(idx); err != nil || !bytes.Equal(got, []byte{'a', idx}) {
+			t.Fatalf("alpha share %d = %q, %v", idx, got, err)
+		}
+		if got, err := b.GetShare(idx); err != nil || !bytes.Equal(got, []byte{'b', idx}) {
+			t.Fatalf("beta share %d = %q, %v", idx, got, err)
+		}
+	}
+	if err := a.DeleteShare(2); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := b.GetShare(2); err != nil || !bytes.Equal(got, []byte{'b', 2}) {
+		t.Fatalf("beta share 2 after deleting alpha's = %q, %v", got, err)
+	}
+}
+
+func TestNamespaceNameTooLong(t *testing.T) {
+	inner := drivers.NewMemoryStorage()
+	ns := storage.NewNamespaces(inner)
+	long := ns.Namespace(strings.Repeat("n", storage.MaxNamespaceLen+1))
+	if err := long.SetShare(1, []byte("share")); err == nil {
+		t.Fatal("SetShare accepted an over-long namespace name")
+	}
+	if err := long.BatchSet(map[byte][]byte{1: []byte("share")}); err == nil {
+		t.Fatal("BatchSet accepted an over-long namespace name")
+	}
+	if indices, _ := inner.ListShares(); len(indices) != 0 {
+		t.Fatalf("inner storage written despite the error: %v", indices)
+	}
+
+	limit := ns.Namespace(strings.Repeat("n", storage.MaxNamespaceLen))
+	if err := limit.SetShare(1, []byte("share")); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := limit.GetShare(1); err != nil || string(got) != "share" {
+		t.Fatalf("GetShare = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,44 @@
+// storage/paged.go
+package storage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PagedLister is implemented by storages that can list indices a page at a
+// time. Pages are in ascending index order. cursor 0 starts a listing, and a
+// returned next of 0 means the listing is complete.
+type PagedLister interface {
+	ListSharesPaged(cursor, limit int) (indices []byte, next int, err error)
+}
+
+// ListSharesPaged returns one page of st's indices, using st's own
+// implementation when available and otherwise paging over ListShares.
+func ListSharesPaged(st IStorage, cursor, limit int) ([]byte, int, error) {
+	if p, ok := st.(PagedLister); ok {
+		return p.ListSharesPaged(cursor, limit)
+	}
+	indices, err := st.ListShares()
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return PageIndices(indices, cursor, limit)
+}
+
+// PageIndices slices one page out of indices sorted in ascending order,
+// with the cursor semantics of PagedLister.
+func PageIndices(sorted []byte, cursor, limit int) ([]byte, int, error) {
+	if cursor < 0 || limit < 1 {
+		return nil, 0, fmt.Errorf("shamir: invalid page cursor %d or limit %d", cursor, limit)
+	}
+	if cursor >= len(sorted) {
+		return []byte{}, 0, nil
+	}
+	end := cursor + limit
+	if end >= len(sorted) {
+		return sorted[cursor:], 0, nil
+	}
+	return sorted[cursor:end], end, nil
+}
@@ -0,0 +1,89 @@
+// storage/ratelimit.go
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedStorage caps the rate of operations sent to an inner storage.
+// Every call, including each BatchSet, consumes one token.
+type RateLimitedStorage struct {
+	inner   ContextStorage
+	limiter *rate.Limiter
+}
+
+// WithRateLimit wraps inner so it receives at most rps operations per second
+// with bursts of up to burst. The returned storage also implements
+// ContextStorage; waiting for a token stops when the context is cancelled.
+// rps must be positive; a zero limit would let only the first burst through
+// and fail every later operation.
+func WithRateLimit(inner IStorage, rps int, burst int) (IStorage, error) {
+	if rps <= 0 {
+		return nil, fmt.Errorf("shamir: rate limit must be positive, got %d", rps)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimitedStorage{
+		inner:   AsContextStorage(inner),
+		limiter: rate.NewLimiter(rate.Limit(rps), burst),
+	}, nil
+}
+
+func (rl *RateLimitedStorage) SetShareContext(ctx context.Context, index byte, share []byte) error {
+	if err := rl.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return rl.inner.SetShareContext(ctx, index, share)
+}
+
+func (rl *RateLimitedStorage) GetShareContext(ctx context.Context, index byte) ([]byte, error) {
+	if err := rl.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return rl.inner.GetShareContext(ctx, index)
+}
+
+func (rl *RateLimitedStorage) ListSharesContext(ctx context.Context) ([]byte, error) {
+	if err := rl.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return rl.inner.ListSharesContext(ctx)
+}
+
+func (rl *RateLimitedStorage) DeleteShareContext(ctx context.Context, index byte) error {
+	if err := rl.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return rl.inner.DeleteShareContext(ctx, index)
+}
+
+func (rl *RateLimitedStorage) BatchSetContext(ctx context.Context, shares map[byte][]byte) error {
+	if err := rl.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return rl.inner.BatchSetContext(ctx, shares)
+}
+
+func (rl *RateLimitedStorage) SetShare(index byte, share []byte) error {
+	return rl.SetShareContext(context.Background(), index, share)
+}
+
+func (rl *RateLimitedStorage) GetShare(index byte) ([]byte, error) {
+	return rl.GetShareContext(context.Background(), index)
+}
+
+func (rl *RateLimitedStorage) ListShares() ([]byte, error) {
+	return rl.ListSharesContext(context.Background())
+}
+
+func (rl *RateLimitedStorage) DeleteShare(index byte) error {
+	return rl.DeleteShareContext(context.Background(), index)
+}
+
+func (rl *RateLimitedStorage) BatchSet(shares map[byte][]byte) error {
+	return rl.BatchSetContext(context.Background(), shares)
+}
@@ -0,0 +1,59 @@
+// storage/ratelimit_test.go
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		rps     int
+		burst   int
+		wantErr bool
+	}{
+		{"positive", 100, 5, false},
+		{"burst below one", 100, 0, false},
+		{"zero", 0, 5, true},
+		{"negative", -1, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := storage.WithRateLimit(drivers.NewMemoryStorage(), tt.rps, tt.burst)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithRateLimit(%d, %d) error = %v, want error: %v", tt.rps, tt.burst, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if err := st.SetShare(1, []byte("x")); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := st.GetShare(1); err != nil || string(got) != "x" {
+				t.Fatalf("GetShare = %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestRateLimitedStorageCancelled(t *testing.T) {
+	st, err := storage.WithRateLimit(drivers.NewMemoryStorage(), 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := st.(storage.ContextStorage)
+	// the first call uses the burst; the next has to wait about a second
+	if err := cs.SetShareContext(context.Background(), 1, []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := cs.GetShareContext(ctx, 1); err == nil {
+		t.Fatal("GetShareContext succeeded without waiting for a token")
+	}
+}
@@ -0,0 +1,93 @@
+// storage/rebalance.go
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ReassignIndex moves the share at index to newBackend: the stored bytes are
+// copied, read back and compared, the assignment is switched, and only then
+// is the share deleted from the old backend. The move holds the
+// MultiStorage lock, so other operations never observe a half-moved index.
+// An index with no stored share is simply reassigned. If the final delete
+// fails the share is already served from newBackend and the error reports
+// the stale copy left behind. Moving between two backends of the same
+// uncomparable type is refused, since they can't be told apart and moving a
+// share onto its own backend would delete it.
+func (ms *MultiStorage) ReassignIndex(index byte, newBackend IStorage) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.reassign(index, newBackend)
+}
+
+// reassign does the work of ReassignIndex. Caller holds ms.mu.
+func (ms *MultiStorage) reassign(index byte, newBackend IStorage) error {
+	old, ok := ms.backends[index]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrNoBackend, index)
+	}
+	if sameBackend(old, newBackend) {
+		return nil
+	}
+	if reflect.TypeOf(old) == reflect.TypeOf(newBackend) && !reflect.ValueOf(newBackend).Comparable() {
+		return fmt.Errorf("shamir: reassign share %d: backends of type %T are not comparable", index, newBackend)
+	}
+	share, err := old.GetShare(index)
+	if errors.Is(err, ErrNotFound) {
+		ms.backends[index] = newBackend
+		delete(ms.names, index)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("shamir: reassign share %d: read: %w", index, err)
+	}
+	if err := newBackend.SetShare(index, share); err != nil {
+		return fmt.Errorf("shamir: reassign share %d: write: %w", index, err)
+	}
+	got, err := newBackend.GetShare(index)
+	if err != nil || !bytes.Equal(got, share) {
+		// leave the old assignment in place; best-effort cleanup of the copy
+		_ = newBackend.DeleteShare(index)
+		if err == nil {
+			err = errors.New("read-back mismatch")
+		}
+		return fmt.Errorf("shamir: reassign share %d: verify: %w", index, err)
+	}
+	ms.backends[index] = newBackend
+	delete(ms.names, index)
+	if err := old.DeleteShare(index); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("shamir: reassign share %d: moved, but old copy not deleted: %w", index, err)
+	}
+	return nil
+}
+
+// Rebalance moves every index assigned to from over to to, one index at a
+// time in ascending order, e.g. to decommission a backend. Indices that fail
+// to move stay assigned to from; their errors are joined. from must be of a
+// comparable type, such as a pointer, so its indices can be found; move the
+// indices of other backends with ReassignIndex.
+func (ms *MultiStorage) Rebalance(from, to IStorage) error {
+	if from != nil && !reflect.ValueOf(from).Comparable() {
+		return fmt.Errorf("shamir: rebalance: backend of type %T is not comparable", from)
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var indices []byte
+	for idx, b := range ms.backends {
+		if sameBackend(b, from) {
+			indices = append(indices, idx)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	var errs []error
+	for _, idx := range indices {
+		if err := ms.reassign(idx, to); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+// storage/rebalance_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestMultiStorageRebalance(t *testing.T) {
+	secret := []byte("decommission me")
+	shares, err := shamir.Split(secret, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, keep, fresh := drivers.NewMemoryStorage(), drivers.NewMemoryStorage(), drivers.NewMemoryStorage()
+	ms := storage.NewMultiStorage()
+	for i, share := range shares {
+		idx := byte(i + 1)
+		backend := keep
+		if idx%2 == 1 {
+			backend = old
+		}
+		ms.AssignStorage(idx, backend)
+		if err := ms.SetShare(idx, share); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ms.Rebalance(old, fresh); err != nil {
+		t.Fatal(err)
+	}
+	if left, err := old.ListShares(); err != nil || len(left) != 0 {
+		t.Fatalf("old backend still holds %v, %v", left, err)
+	}
+	moved, err := fresh.ListSharesSorted()
+	if err != nil || !bytes.Equal(moved, []byte{1, 3, 5}) {
+		t.Fatalf("new backend holds %v, %v, want [1 3 5]", moved, err)
+	}
+	if err := ms.ReassignIndex(2, fresh); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keep.GetShare(2); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("share 2 left on its old backend: %v", err)
+	}
+
+	got, err := storage.RetrieveSharesMulti([]byte{1, 2, 3, 4, 5}, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combined, err := shamir.Combine(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(combined, secret) {
+		t.Fatalf("combined %q, want %q", combined, secret)
+	}
+}
+
+func TestMultiStorageReassignUnassigned(t *testing.T) {
+	ms := storage.NewMultiStorage()
+	if err := ms.ReassignIndex(9, drivers.NewMemoryStorage()); !errors.Is(err, storage.ErrNoBackend) {
+		t.Fatalf("ReassignIndex of an unassigned index = %v, want ErrNoBackend", err)
+	}
+}
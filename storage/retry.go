@@ -0,0 +1,141 @@
+// storage/retry.go
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how WithRetry retries failed storage operations.
+type RetryPolicy struct {
+	MaxAttempts int              // total attempts including the first; < 1 means 1
+	BaseDelay   time.Duration    // delay before the first retry, doubled each attempt
+	MaxDelay    time.Duration    // optional cap on the backoff delay
+	Retryable   func(error) bool // reports whether an error is transient; nil retries all errors
+}
+
+// RetryStorage retries transient failures of an inner storage with
+// exponential backoff and jitter.
+type RetryStorage struct {
+	inner  ContextStorage
+	policy RetryPolicy
+}
+
+// WithRetry wraps inner so each operation is retried according to policy.
+// The returned storage also implements ContextStorage; cancelling the
+// context stops any pending backoff.
+func WithRetry(inner IStorage, policy RetryPolicy) IStorage {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &RetryStorage{inner: AsContextStorage(inner), policy: policy}
+}
+
+// do runs op until it succeeds, returns a non-retryable error, runs out of
+// attempts, or ctx is done. In the last case the error wraps both ctx.Err()
+// and the last error of op.
+func (rs *RetryStorage) do(ctx context.Context, op func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = op(); err == nil {
+			return nil
+		}
+		if attempt >= rs.policy.MaxAttempts {
+			return err
+		}
+		if rs.policy.Retryable != nil && !rs.policy.Retryable(err) {
+			return err
+		}
+		timer := time.NewTimer(rs.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: last attempt: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the jittered delay before retry number attempt.
+func (rs *RetryStorage) backoff(attempt int) time.Duration {
+	maxDelay := rs.policy.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+	d := min(rs.policy.BaseDelay, maxDelay)
+	// double without overflowing, stopping at the cap
+	for i := 1; i < attempt && d > 0 && d < maxDelay; i++ {
+		if d > maxDelay/2 {
+			d = maxDelay
+		} else {
+			d *= 2
+		}
+	}
+	if d <= 0 {
+		return 0
+	}
+	// jitter in [d/2, d)
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(d-half)))
+}
+
+func (rs *RetryStorage) SetShareContext(ctx context.Context, index byte, share []byte) error {
+	return rs.do(ctx, func() error {
+		return rs.inner.SetShareContext(ctx, index, share)
+	})
+}
+
+func (rs *RetryStorage) GetShareContext(ctx context.Context, index byte) ([]byte, error) {
+	var out []byte
+	err := rs.do(ctx, func() error {
+		var err error
+		out, err = rs.inner.GetShareContext(ctx, index)
+		return err
+	})
+	return out, err
+}
+
+func (rs *RetryStorage) ListSharesContext(ctx context.Context) ([]byte, error) {
+	var out []byte
+	err := rs.do(ctx, func() error {
+		var err error
+		out, err = rs.inner.ListSharesContext(ctx)
+		return err
+	})
+	return out, err
+}
+
+func (rs *RetryStorage) DeleteShareContext(ctx context.Context, index byte) error {
+	return rs.do(ctx, func() error {
+		return rs.inner.DeleteShareContext(ctx, index)
+	})
+}
+
+func (rs *RetryStorage) BatchSetContext(ctx context.Context, shares map[byte][]byte) error {
+	return rs.do(ctx, func() error {
+		return rs.inner.BatchSetContext(ctx, shares)
+	})
+}
+
+func (rs *RetryStorage) SetShare(index byte, share []byte) error {
+	return rs.SetShareContext(context.Background(), index, share)
+}
+
+func (rs *RetryStorage) GetShare(index byte) ([]byte, error) {
+	return rs.GetShareContext(context.Background(), index)
+}
+
+func (rs *RetryStorage) ListShares() ([]byte, error) {
+	return rs.ListSharesContext(context.Background())
+}
+
+func (rs *RetryStorage) DeleteShare(index byte) error {
+	return rs.DeleteShareContext(context.Background(), index)
+}
+
+func (rs *RetryStorage) BatchSet(shares map[byte][]byte) error {
+	return rs.BatchSetContext(context.Background(), shares)
+}
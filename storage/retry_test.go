@@ -0,0 +1,94 @@
+// storage/retry_test.go
+package storage
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		min, max time.Duration // result must lie in [min, max)
+	}{
+		{"first retry", RetryPolicy{BaseDelay: 100 * time.Millisecond}, 1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"doubled", RetryPolicy{BaseDelay: 100 * time.Millisecond}, 3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{"capped", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 10, 500 * time.Millisecond, time.Second},
+		{"huge attempt capped", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, 200, 30 * time.Second, time.Minute},
+		{"huge attempt uncapped", RetryPolicy{BaseDelay: time.Second}, 200, math.MaxInt64 / 2, math.MaxInt64},
+		{"base above cap", RetryPolicy{BaseDelay: time.Hour, MaxDelay: time.Second}, 1, 500 * time.Millisecond, time.Second},
+		{"no delay", RetryPolicy{}, 5, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &RetryStorage{policy: tt.policy}
+			for range 100 {
+				d := rs.backoff(tt.attempt)
+				if d < tt.min || d >= tt.max {
+					t.Fatalf("backoff(%d) = %v, want in [%v, %v)", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+var errFlaky = errors.New("flaky")
+
+// flakyStorage fails the first fails calls of GetShare.
+type flakyStorage struct {
+	IStorage
+	fails, calls int
+}
+
+func (f *flakyStorage) GetShare(index byte) ([]byte, error) {
+	f.calls++
+	if f.calls <= f.fails {
+		return nil, errFlaky
+	}
+	return []byte{index}, nil
+}
+
+func TestRetryStorage(t *testing.T) {
+	tests := []struct {
+		name      string
+		fails     int
+		policy    RetryPolicy
+		wantErr   error
+		wantCalls int
+	}{
+		{"succeeds after retries", 2, RetryPolicy{MaxAttempts: 3}, nil, 3},
+		{"runs out of attempts", 5, RetryPolicy{MaxAttempts: 3}, errFlaky, 3},
+		{"not retryable", 5, RetryPolicy{MaxAttempts: 3, Retryable: func(error) bool { return false }}, errFlaky, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &flakyStorage{fails: tt.fails}
+			_, err := WithRetry(inner, tt.policy).GetShare(1)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("GetShare error = %v, want %v", err, tt.wantErr)
+			}
+			if inner.calls != tt.wantCalls {
+				t.Fatalf("inner called %d times, want %d", inner.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryStorageCancelled(t *testing.T) {
+	inner := &flakyStorage{fails: 100}
+	rs := WithRetry(inner, RetryPolicy{MaxAttempts: 10, BaseDelay: time.Hour}).(*RetryStorage)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := rs.GetShareContext(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if !errors.Is(err, errFlaky) {
+		t.Fatalf("error = %v, want it to wrap the last attempt's error", err)
+	}
+}
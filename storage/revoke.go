@@ -0,0 +1,113 @@
+// storage/revoke.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrRevoked is returned for operations on a revoked share index.
+var ErrRevoked = errors.New("shamir: share index revoked")
+
+var _ IStorage = (*RevocableStorage)(nil)
+
+// RevocableStorage wraps a storage with a revocation list. Revoked indices
+// are hidden from ListShares, refused by GetShare and SetShare, and reported
+// by Revoked, which shamir.CombineFromStorage consults. The list is kept in
+// memory; persist RevokedIndices and pass it back to WithRevocation to carry
+// it across restarts.
+type RevocableStorage struct {
+	inner   IStorage
+	mu      sync.RWMutex
+	revoked map[byte]bool
+}
+
+// WithRevocation wraps inner, starting from an existing revocation list.
+func WithRevocation(inner IStorage, revoked []byte) IStorage {
+	rs := &RevocableStorage{inner: inner, revoked: make(map[byte]bool, len(revoked))}
+	for _, idx := range revoked {
+		rs.revoked[idx] = true
+	}
+	return rs
+}
+
+// Revoke deletes the share at index from the inner storage and records the
+// revocation. The index is recorded even if nothing was stored there.
+func (rs *RevocableStorage) Revoke(index byte) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.revoked[index] = true
+	if err := rs.inner.DeleteShare(index); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("shamir: revoke share %d: %w", index, err)
+	}
+	return nil
+}
+
+// Revoked reports whether index has been revoked.
+func (rs *RevocableStorage) Revoked(index byte) bool {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.revoked[index]
+}
+
+// RevokedIndices returns the revocation list in ascending order.
+func (rs *RevocableStorage) RevokedIndices() []byte {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	out := make([]byte, 0, len(rs.revoked))
+	for idx := range rs.revoked {
+		out = append(out, idx)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func (rs *RevocableStorage) SetShare(index byte, share []byte) error {
+	if rs.Revoked(index) {
+		return fmt.Errorf("%w: %d", ErrRevoked, index)
+	}
+	return rs.inner.SetShare(index, share)
+}
+
+func (rs *RevocableStorage) GetShare(index byte) ([]byte, error) {
+	if rs.Revoked(index) {
+		return nil, fmt.Errorf("%w: %d", ErrRevoked, index)
+	}
+	return rs.inner.GetShare(index)
+}
+
+// ListShares lists the inner storage's indices minus the revoked ones.
+func (rs *RevocableStorage) ListShares() ([]byte, error) {
+	indices, err := rs.inner.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	kept := indices[:0]
+	for _, idx := range indices {
+		if !rs.revoked[idx] {
+			kept = append(kept, idx)
+		}
+	}
+	return kept, nil
+}
+
+func (rs *RevocableStorage) DeleteShare(index byte) error {
+	return rs.inner.DeleteShare(index)
+}
+
+// BatchSet refuses the whole batch if it contains a revoked index.
+func (rs *RevocableStorage) BatchSet(shares map[byte][]byte) error {
+	rs.mu.RLock()
+	for idx := range shares {
+		if rs.revoked[idx] {
+			rs.mu.RUnlock()
+			return fmt.Errorf("%w: %d", ErrRevoked, idx)
+		}
+	}
+	rs.mu.RUnlock()
+	return rs.inner.BatchSet(shares)
+}
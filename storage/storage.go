@@ -3,7 +3,12 @@ package storage
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
 	"sync"
+
+	"github.com/oarkflow/shamir"
 )
 
 // IStorage defines storage operations for shares.
@@ -15,22 +20,104 @@ type IStorage interface {
 	BatchSet(shares map[byte][]byte) error
 }
 
+// ErrNotFound is wrapped by drivers when a share index is not stored.
+var ErrNotFound = errors.New("share not found")
+
+// ErrNoBackend is returned when a share index has no assigned backend.
+var ErrNoBackend = errors.New("shamir: no storage backend assigned for share index")
+
+var _ IStorage = (*MultiStorage)(nil)
+
 // MultiStorage allows different storage backends per share index.
 type MultiStorage struct {
 	mu       sync.RWMutex
 	backends map[byte]IStorage
+	costs    map[byte]int
+	registry map[string]IStorage // backends registered by name
+	names    map[byte]string     // registered name each index was assigned by, for topology export
 }
 
 // NewMultiStorage returns a new MultiStorage instance.
 func NewMultiStorage() *MultiStorage {
-	return &MultiStorage{backends: make(map[byte]IStorage)}
+	return &MultiStorage{
+		backends: make(map[byte]IStorage),
+		costs:    make(map[byte]int),
+	}
 }
 
 // AssignStorage assigns a specific storage backend for a share index.
 func (ms *MultiStorage) AssignStorage(index byte, backend IStorage) {
+	ms.AssignStorageWithCost(index, backend, 0)
+}
+
+// AssignStorageWithCost assigns a backend for a share index along with a
+// relative retrieval cost used by QuorumPlan (lower is cheaper).
+func (ms *MultiStorage) AssignStorageWithCost(index byte, backend IStorage, cost int) {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 	ms.backends[index] = backend
+	ms.costs[index] = cost
+	delete(ms.names, index)
+}
+
+// AssignRange assigns one backend to every index in [start, end].
+func (ms *MultiStorage) AssignRange(start, end byte, backend IStorage) error {
+	if start == 0 {
+		return errors.New("shamir: share index 0 is invalid")
+	}
+	if start > end {
+		return fmt.Errorf("shamir: invalid index range %d..%d", start, end)
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for i := int(start); i <= int(end); i++ {
+		ms.backends[byte(i)] = backend
+		ms.costs[byte(i)] = 0
+		delete(ms.names, byte(i))
+	}
+	return nil
+}
+
+// AssignAll assigns one backend to each of the given indices.
+func (ms *MultiStorage) AssignAll(backend IStorage, indices []byte) error {
+	for _, idx := range indices {
+		if idx == 0 {
+			return errors.New("shamir: share index 0 is invalid")
+		}
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for _, idx := range indices {
+		ms.backends[idx] = backend
+		ms.costs[idx] = 0
+		delete(ms.names, idx)
+	}
+	return nil
+}
+
+// QuorumPlan returns threshold assigned indices to retrieve, cheapest first.
+// Ties are broken by ascending index.
+func (ms *MultiStorage) QuorumPlan(threshold int) ([]byte, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if threshold < 1 {
+		return nil, errors.New("shamir: threshold must be positive")
+	}
+	if len(ms.backends) < threshold {
+		return nil, fmt.Errorf("shamir: quorum unreachable: %d backends assigned, need %d", len(ms.backends), threshold)
+	}
+	indices := make([]byte, 0, len(ms.backends))
+	for idx := range ms.backends {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		ci, cj := ms.costs[indices[i]], ms.costs[indices[j]]
+		if ci != cj {
+			return ci < cj
+		}
+		return indices[i] < indices[j]
+	})
+	return indices[:threshold], nil
 }
 
 // SetShare stores a share in its designated storage backend.
@@ -39,7 +126,7 @@ func (ms *MultiStorage) SetShare(index byte, share []byte) error {
 	backend, ok := ms.backends[index]
 	ms.mu.RUnlock()
 	if !ok {
-		return errors.New("shamir: no storage backend assigned for share index")
+		return fmt.Errorf("%w: %d", ErrNoBackend, index)
 	}
 	return backend.SetShare(index, share)
 }
@@ -50,7 +137,7 @@ func (ms *MultiStorage) GetShare(index byte) ([]byte, error) {
 	backend, ok := ms.backends[index]
 	ms.mu.RUnlock()
 	if !ok {
-		return nil, errors.New("shamir: no storage backend assigned for share index")
+		return nil, fmt.Errorf("%w: %d", ErrNoBackend, index)
 	}
 	return backend.GetShare(index)
 }
@@ -72,19 +159,95 @@ func (ms *MultiStorage) DeleteShare(index byte) error {
 	backend, ok := ms.backends[index]
 	ms.mu.RUnlock()
 	if !ok {
-		return errors.New("shamir: no storage backend assigned for share index")
+		return fmt.Errorf("%w: %d", ErrNoBackend, index)
 	}
 	return backend.DeleteShare(index)
 }
 
+// maxBatchWorkers bounds how many backends MultiStorage.BatchSet writes to
+// at once.
+const maxBatchWorkers = 8
+
 // BatchSet stores multiple shares across potentially different backends.
+// Shares are grouped by backend and each group is written with that
+// backend's own BatchSet, so per-backend atomicity is preserved, while
+// different backends are written concurrently. Every index must have a
+// backend assigned; otherwise nothing is written. Failures of individual
+// backends are joined into the returned error.
 func (ms *MultiStorage) BatchSet(shares map[byte][]byte) error {
-	for idx, share := range shares {
-		if err := ms.SetShare(idx, share); err != nil {
-			return err
+	var groups []backendGroup
+	ms.mu.RLock()
+	for _, idx := range SortedIndices(shares) {
+		backend, ok := ms.backends[idx]
+		if !ok {
+			ms.mu.RUnlock()
+			return fmt.Errorf("%w: %d", ErrNoBackend, idx)
 		}
+		g := findGroup(&groups, backend)
+		if g.shares == nil {
+			g.shares = make(map[byte][]byte)
+		}
+		g.shares[idx] = shares[idx]
 	}
-	return nil
+	ms.mu.RUnlock()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, maxBatchWorkers)
+	)
+	for _, g := range groups {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := g.backend.BatchSet(g.shares); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("shamir: shares %v: %w", SortedIndices(g.shares), err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// backendGroup collects the indices assigned to one backend.
+type backendGroup struct {
+	backend IStorage
+	indices []byte
+	shares  map[byte][]byte
+}
+
+// findGroup returns the group for backend, appending a new one if needed.
+// Groups are kept in a slice rather than a map keyed by IStorage, since
+// such a map panics on backends whose dynamic type isn't comparable.
+func findGroup(groups *[]backendGroup, backend IStorage) *backendGroup {
+	for i := range *groups {
+		if sameBackend((*groups)[i].backend, backend) {
+			return &(*groups)[i]
+		}
+	}
+	*groups = append(*groups, backendGroup{backend: backend})
+	return &(*groups)[len(*groups)-1]
+}
+
+// sameBackend reports whether a and b are the same backend. Unlike ==, it
+// doesn't panic on backends whose dynamic type isn't comparable, such as a
+// slice or a struct holding a map; those never compare equal, not even to
+// themselves.
+func sameBackend(a, b IStorage) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !reflect.ValueOf(a).Comparable() || !reflect.ValueOf(b).Comparable() {
+		return false
+	}
+	return a == b
 }
 
 // StoreSharesMulti is a convenience wrapper to store a slice of shares.
@@ -94,14 +257,17 @@ func StoreSharesMulti(shares [][]byte, ms *MultiStorage) error {
 		if len(s) == 0 {
 			continue
 		}
-		// Modified: Use share index from offset 9 instead of 0
-		idx := s[9]
+		idx, err := shamir.ShareIndex(s)
+		if err != nil {
+			return err
+		}
 		batch[idx] = s
 	}
 	return ms.BatchSet(batch)
 }
 
-// RetrieveSharesMulti retrieves shares by index from the multi-storage.
+// RetrieveSharesMulti retrieves shares by index from the multi-storage,
+// in the order the indices are given.
 func RetrieveSharesMulti(indices []byte, ms *MultiStorage) ([][]byte, error) {
 	var out [][]byte
 	for _, idx := range indices {
@@ -113,3 +279,13 @@ func RetrieveSharesMulti(indices []byte, ms *MultiStorage) ([][]byte, error) {
 	}
 	return out, nil
 }
+
+// ListSharesSorted lists assigned indices in ascending order.
+func (ms *MultiStorage) ListSharesSorted() ([]byte, error) {
+	indices, err := ms.ListShares()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
+}
@@ -0,0 +1,193 @@
+// storage/storagetest/storagetest.go
+
+// Package storagetest provides a conformance suite for IStorage
+// implementations.
+package storagetest
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+// StorageConformanceTest runs the IStorage contract against stores returned
+// by newStore. Each subtest gets a fresh, empty store.
+func StorageConformanceTest(t *testing.T, newStore func() storage.IStorage) {
+	t.Helper()
+
+	t.Run("SetGet", func(t *testing.T) {
+		st := newStore()
+		want := []byte{1, 2, 3}
+		if err := st.SetShare(1, want); err != nil {
+			t.Fatalf("SetShare: %v", err)
+		}
+		got, err := st.GetShare(1)
+		if err != nil {
+			t.Fatalf("GetShare: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("GetShare = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		st := newStore()
+		if _, err := st.GetShare(7); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("GetShare on missing index: got %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		st := newStore()
+		if err := st.SetShare(2, []byte{1}); err != nil {
+			t.Fatalf("SetShare: %v", err)
+		}
+		if err := st.SetShare(2, []byte{9, 9}); err != nil {
+			t.Fatalf("SetShare overwrite: %v", err)
+		}
+		got, err := st.GetShare(2)
+		if err != nil {
+			t.Fatalf("GetShare: %v", err)
+		}
+		if !bytes.Equal(got, []byte{9, 9}) {
+			t.Fatalf("GetShare after overwrite = %x, want 0909", got)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		st := newStore()
+		for _, idx := range []byte{3, 1, 2} {
+			if err := st.SetShare(idx, []byte{idx}); err != nil {
+				t.Fatalf("SetShare(%d): %v", idx, err)
+			}
+		}
+		got, err := st.ListShares()
+		if err != nil {
+			t.Fatalf("ListShares: %v", err)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if !bytes.Equal(got, []byte{1, 2, 3}) {
+			t.Fatalf("ListShares = %v, want [1 2 3]", got)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		st := newStore()
+		if err := st.SetShare(4, []byte{4}); err != nil {
+			t.Fatalf("SetShare: %v", err)
+		}
+		if err := st.DeleteShare(4); err != nil {
+			t.Fatalf("DeleteShare: %v", err)
+		}
+		if _, err := st.GetShare(4); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("GetShare after delete: got %v, want ErrNotFound", err)
+		}
+		idx, err := st.ListShares()
+		if err != nil {
+			t.Fatalf("ListShares: %v", err)
+		}
+		if len(idx) != 0 {
+			t.Fatalf("ListShares after delete = %v, want empty", idx)
+		}
+	})
+
+	t.Run("DeleteAll", func(t *testing.T) {
+		st := newStore()
+		if err := st.BatchSet(map[byte][]byte{1: {1}, 2: {2}, 3: {3}}); err != nil {
+			t.Fatalf("BatchSet: %v", err)
+		}
+		if err := storage.DeleteAll(st); err != nil {
+			t.Fatalf("DeleteAll: %v", err)
+		}
+		idx, err := st.ListShares()
+		if err != nil {
+			t.Fatalf("ListShares: %v", err)
+		}
+		if len(idx) != 0 {
+			t.Fatalf("ListShares after DeleteAll = %v, want empty", idx)
+		}
+		if err := storage.DeleteAll(st); err != nil {
+			t.Fatalf("DeleteAll on empty store: %v", err)
+		}
+	})
+
+	t.Run("BatchDelete", func(t *testing.T) {
+		st := newStore()
+		if err := st.BatchSet(map[byte][]byte{1: {1}, 2: {2}, 3: {3}}); err != nil {
+			t.Fatalf("BatchSet: %v", err)
+		}
+		err := storage.BatchDelete(st, []byte{1, 3, 8})
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("BatchDelete with a missing index: got %v, want ErrNotFound", err)
+		}
+		idx, err := st.ListShares()
+		if err != nil {
+			t.Fatalf("ListShares: %v", err)
+		}
+		if !bytes.Equal(idx, []byte{2}) {
+			t.Fatalf("ListShares after BatchDelete = %v, want [2]", idx)
+		}
+	})
+
+	t.Run("DeleteMissing", func(t *testing.T) {
+		st := newStore()
+		if err := st.DeleteShare(5); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("DeleteShare on missing index: got %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("BatchSet", func(t *testing.T) {
+		st := newStore()
+		batch := map[byte][]byte{1: {1}, 2: {2, 2}, 3: {3, 3, 3}}
+		if err := st.BatchSet(batch); err != nil {
+			t.Fatalf("BatchSet: %v", err)
+		}
+		for idx, want := range batch {
+			got, err := st.GetShare(idx)
+			if err != nil {
+				t.Fatalf("GetShare(%d): %v", idx, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("GetShare(%d) = %x, want %x", idx, got, want)
+			}
+		}
+	})
+
+	t.Run("CopyOnWrite", func(t *testing.T) {
+		st := newStore()
+		in := []byte{1, 2, 3}
+		if err := st.SetShare(1, in); err != nil {
+			t.Fatalf("SetShare: %v", err)
+		}
+		in[0] = 0xFF
+		got, err := st.GetShare(1)
+		if err != nil {
+			t.Fatalf("GetShare: %v", err)
+		}
+		if got[0] != 1 {
+			t.Fatal("mutating the input slice changed the stored share")
+		}
+	})
+
+	t.Run("CopyOnRead", func(t *testing.T) {
+		st := newStore()
+		if err := st.SetShare(1, []byte{1, 2, 3}); err != nil {
+			t.Fatalf("SetShare: %v", err)
+		}
+		got, err := st.GetShare(1)
+		if err != nil {
+			t.Fatalf("GetShare: %v", err)
+		}
+		got[0] = 0xFF
+		again, err := st.GetShare(1)
+		if err != nil {
+			t.Fatalf("GetShare: %v", err)
+		}
+		if again[0] != 1 {
+			t.Fatal("mutating a returned slice changed the stored share")
+		}
+	})
+}
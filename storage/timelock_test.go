@@ -0,0 +1,106 @@
+// storage/timelock_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/oarkflow/shamir"
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+// manualClock reports a time that only moves when the test sets it. Tickers
+// are real; TimeLockStorage never starts one.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+func (c *manualClock) NewTicker(d time.Duration) shamir.Ticker {
+	return shamir.SystemClock.NewTicker(d)
+}
+
+func TestTimeLockStorage(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	inner := drivers.NewMemoryStorage()
+	ts := storage.NewTimeLockStorage(inner, map[byte]time.Time{
+		2: start.Add(time.Hour),
+		3: start.Add(2 * time.Hour),
+	}, clock)
+	for idx := byte(1); idx <= 3; idx++ {
+		if err := ts.SetShare(idx, []byte{idx}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Duration
+		open    []byte
+		locked  []byte
+		visible []byte
+	}{
+		{"before any release", 0, []byte{1}, []byte{2, 3}, []byte{1}},
+		{"just before release", time.Hour - time.Nanosecond, []byte{1}, []byte{2, 3}, []byte{1}},
+		{"at release time", time.Hour, []byte{1, 2}, []byte{3}, []byte{1, 2}},
+		{"after every release", 3 * time.Hour, []byte{1, 2, 3}, nil, []byte{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.now = start.Add(tt.at)
+			for _, idx := range tt.open {
+				got, err := ts.GetShare(idx)
+				if err != nil || !bytes.Equal(got, []byte{idx}) {
+					t.Errorf("GetShare(%d) = %v, %v", idx, got, err)
+				}
+			}
+			for _, idx := range tt.locked {
+				if _, err := ts.GetShare(idx); !errors.Is(err, storage.ErrTimeLocked) {
+					t.Errorf("GetShare(%d) = %v, want ErrTimeLocked", idx, err)
+				}
+			}
+
+			ts.HideLocked = false
+			all, err := ts.ListShares()
+			if err != nil || len(all) != 3 {
+				t.Errorf("ListShares without HideLocked = %v, %v, want all three", all, err)
+			}
+			ts.HideLocked = true
+			visible, err := ts.ListShares()
+			if err != nil {
+				t.Fatal(err)
+			}
+			slices.Sort(visible)
+			if !bytes.Equal(visible, tt.visible) {
+				t.Errorf("ListShares with HideLocked = %v, want %v", visible, tt.visible)
+			}
+		})
+	}
+}
+
+func TestTimeLockStorageSetReleaseTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	ts := storage.NewTimeLockStorage(drivers.NewMemoryStorage(), nil, clock)
+	if err := ts.SetShare(1, []byte("share")); err != nil {
+		t.Fatal(err)
+	}
+
+	ts.SetReleaseTime(1, start.Add(time.Minute))
+	if locked, at := ts.Locked(1); !locked || !at.Equal(start.Add(time.Minute)) {
+		t.Fatalf("Locked(1) = %v, %v", locked, at)
+	}
+	if _, err := ts.GetShare(1); !errors.Is(err, storage.ErrTimeLocked) {
+		t.Fatalf("GetShare = %v, want ErrTimeLocked", err)
+	}
+	ts.SetReleaseTime(1, time.Time{})
+	if _, err := ts.GetShare(1); err != nil {
+		t.Fatalf("GetShare after removing the lock = %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// storage/topology.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// TopologyEntry records which named backend serves a share index.
+type TopologyEntry struct {
+	Index   byte   `json:"index"`
+	Backend string `json:"backend"`
+	Cost    int    `json:"cost,omitempty"`
+}
+
+// RegisterBackend names a backend so indices can be assigned to it with
+// AssignNamed and exported by name. Registering a name again replaces the
+// backend it refers to for later assignments.
+func (ms *MultiStorage) RegisterBackend(name string, backend IStorage) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.registry == nil {
+		ms.registry = make(map[string]IStorage)
+	}
+	ms.registry[name] = backend
+}
+
+// AssignNamed assigns the backend registered under name to index.
+// Assignments are tracked by name rather than by backend identity, so any
+// backend type can be exported, including ones that aren't comparable.
+func (ms *MultiStorage) AssignNamed(index byte, name string) error {
+	if index == 0 {
+		return errors.New("shamir: share index 0 is invalid")
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	backend, ok := ms.registry[name]
+	if !ok {
+		return fmt.Errorf("shamir: unknown backend %q for index %d", name, index)
+	}
+	ms.backends[index] = backend
+	ms.costs[index] = 0
+	ms.setName(index, name)
+	return nil
+}
+
+// setName records the registered name index was assigned by. Caller holds
+// ms.mu.
+func (ms *MultiStorage) setName(index byte, name string) {
+	if ms.names == nil {
+		ms.names = make(map[byte]string)
+	}
+	ms.names[index] = name
+}
+
+// ExportTopology lists every index assignment in ascending index order.
+// Indices that weren't assigned with AssignNamed or ImportTopology are
+// exported with an empty backend name.
+func (ms *MultiStorage) ExportTopology() []TopologyEntry {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	entries := make([]TopologyEntry, 0, len(ms.backends))
+	for idx := range ms.backends {
+		entries = append(entries, TopologyEntry{
+			Index:   idx,
+			Backend: ms.names[idx],
+			Cost:    ms.costs[idx],
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+	return entries
+}
+
+// ImportTopology assigns backends from a previously exported topology,
+// resolving names through registry, which is also registered as with
+// RegisterBackend. Nothing is applied unless every entry resolves.
+func (ms *MultiStorage) ImportTopology(entries []TopologyEntry, registry map[string]IStorage) error {
+	for _, e := range entries {
+		if e.Index == 0 {
+			return errors.New("shamir: share index 0 is invalid")
+		}
+		if _, ok := registry[e.Backend]; !ok {
+			return fmt.Errorf("shamir: unknown backend %q for index %d", e.Backend, e.Index)
+		}
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.registry == nil {
+		ms.registry = make(map[string]IStorage)
+	}
+	for name, b := range registry {
+		ms.registry[name] = b
+	}
+	for _, e := range entries {
+		ms.backends[e.Index] = registry[e.Backend]
+		ms.costs[e.Index] = e.Cost
+		ms.setName(e.Index, e.Backend)
+	}
+	return nil
+}
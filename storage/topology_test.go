@@ -0,0 +1,118 @@
+// storage/topology_test.go
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/drivers"
+)
+
+func TestTopologyRoundTrip(t *testing.T) {
+	registry := map[string]storage.IStorage{
+		"mem":   drivers.NewMemoryStorage(),
+		"slice": sliceStorage{drivers.NewMemoryStorage()},
+	}
+	ms := storage.NewMultiStorage()
+	for name, b := range registry {
+		ms.RegisterBackend(name, b)
+	}
+	for idx, name := range map[byte]string{1: "mem", 2: "slice", 3: "mem"} {
+		if err := ms.AssignNamed(idx, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ms.AssignStorageWithCost(4, drivers.NewMemoryStorage(), 7)
+	if err := ms.AssignNamed(5, "missing"); err == nil {
+		t.Fatal("AssignNamed accepted an unregistered name")
+	}
+
+	exported := ms.ExportTopology()
+	want := []storage.TopologyEntry{
+		{Index: 1, Backend: "mem"},
+		{Index: 2, Backend: "slice"},
+		{Index: 3, Backend: "mem"},
+		{Index: 4, Cost: 7},
+	}
+	if !reflect.DeepEqual(exported, want) {
+		t.Fatalf("ExportTopology = %+v, want %+v", exported, want)
+	}
+
+	restored := storage.NewMultiStorage()
+	if err := restored.ImportTopology(exported[:3], registry); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.ExportTopology(); !reflect.DeepEqual(got, want[:3]) {
+		t.Fatalf("imported topology = %+v, want %+v", got, want[:3])
+	}
+	if err := restored.SetShare(2, []byte("two")); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := registry["slice"].GetShare(2); err != nil || string(got) != "two" {
+		t.Fatalf("share 2 on slice backend = %q, %v", got, err)
+	}
+
+	// reassigning by backend drops the name
+	restored.AssignStorage(1, drivers.NewMemoryStorage())
+	if got := restored.ExportTopology()[0]; got.Backend != "" {
+		t.Fatalf("index 1 still exported as %q", got.Backend)
+	}
+}
+
+// TestMultiStorageUncomparableBackends checks that operations which group or
+// match backends don't panic when a backend's type isn't comparable.
+func TestMultiStorageUncomparableBackends(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(ms *storage.MultiStorage, slice, mem storage.IStorage) error
+		wantErr bool
+	}{
+		{"BatchSet", func(ms *storage.MultiStorage, _, _ storage.IStorage) error {
+			return ms.BatchSet(map[byte][]byte{1: []byte("x"), 2: []byte("y"), 3: []byte("z")})
+		}, false},
+		{"BatchDelete", func(ms *storage.MultiStorage, _, _ storage.IStorage) error {
+			return ms.BatchDelete([]byte{1, 2, 3})
+		}, false},
+		{"ReassignIndex to other type", func(ms *storage.MultiStorage, _, mem storage.IStorage) error {
+			return ms.ReassignIndex(1, mem)
+		}, false},
+		{"ReassignIndex to same type", func(ms *storage.MultiStorage, _, _ storage.IStorage) error {
+			return ms.ReassignIndex(1, sliceStorage{drivers.NewMemoryStorage()})
+		}, true},
+		{"Rebalance from uncomparable", func(ms *storage.MultiStorage, slice, mem storage.IStorage) error {
+			return ms.Rebalance(slice, mem)
+		}, true},
+		{"Rebalance to uncomparable", func(ms *storage.MultiStorage, slice, mem storage.IStorage) error {
+			return ms.Rebalance(mem, slice)
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slice := sliceStorage{drivers.NewMemoryStorage()}
+			mem := drivers.NewMemoryStorage()
+			ms := storage.NewMultiStorage()
+			ms.AssignStorage(1, slice)
+			ms.AssignStorage(2, slice)
+			ms.AssignStorage(3, mem)
+			if err := ms.BatchSet(map[byte][]byte{1: []byte("a"), 2: []byte("b"), 3: []byte("c")}); err != nil {
+				t.Fatal(err)
+			}
+			err := tt.op(ms, slice, mem)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error: %v", err, tt.wantErr)
+			}
+			if errors.Is(err, storage.ErrNotFound) {
+				t.Fatalf("unexpected ErrNotFound: %v", err)
+			}
+			if tt.wantErr {
+				// a refused move leaves the share where it was
+				if got, err := slice.GetShare(1); err != nil || !bytes.Equal(got, []byte("a")) {
+					t.Fatalf("share 1 = %q, %v after refused move", got, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// storage/vaultstore/integration_test.go
+
+//go:build integration
+
+package vaultstore
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+// TestKVStorageIntegration runs the conformance suite against a real Vault,
+// e.g. a dev server started with
+//
+//	vault server -dev -dev-root-token-id=root
+//
+// and VAULT_ADDR=http://127.0.0.1:8200 VAULT_TOKEN=root set. The dev
+// server mounts a KV v2 engine at "secret". Run with -tags integration.
+func TestKVStorageIntegration(t *testing.T) {
+	if os.Getenv("VAULT_ADDR") == "" || os.Getenv("VAULT_TOKEN") == "" {
+		t.Skip("VAULT_ADDR and VAULT_TOKEN not set")
+	}
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mount := os.Getenv("VAULT_KV_MOUNT")
+	if mount == "" {
+		mount = "secret"
+	}
+	run := time.Now().UnixNano()
+	n := 0
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		n++
+		path := fmt.Sprintf("shamir-test/%d/%d", run, n)
+		st := NewKVStorage(client, mount, path)
+		t.Cleanup(func() {
+			indices, _ := st.ListShares()
+			for _, idx := range indices {
+				_ = st.DeleteShare(idx)
+			}
+		})
+		return st
+	})
+}
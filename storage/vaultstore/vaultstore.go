@@ -0,0 +1,170 @@
+// storage/vaultstore/vaultstore.go
+
+// Package vaultstore stores shares in a HashiCorp Vault KV version 2 engine.
+// It is separate from the drivers package so that only programs using it
+// depend on the Vault client.
+package vaultstore
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+
+	"github.com/oarkflow/shamir/storage"
+)
+
+// vaultField is the secret field holding the base64 share.
+const vaultField = "share"
+
+var (
+	_ storage.IStorage       = (*KVStorage)(nil)
+	_ storage.ContextStorage = (*KVStorage)(nil)
+)
+
+// KVStorage implements IStorage on a HashiCorp Vault KV version 2
+// engine. Each share is the secret <mount>/data/<path>/share_<index>, with
+// the share base64-encoded in its "share" field. Requests go through the
+// given client, so its address, token, namespace and retry settings apply;
+// the token needs create, read, update, delete and list on
+// <mount>/data/<path>/* and <mount>/metadata/<path>/*.
+type KVStorage struct {
+	client *vault.Client
+	kv     *vault.KVv2
+	mount  string
+	path   string
+}
+
+// NewKVStorage returns the storage under path in the KV v2 engine
+// mounted at mount.
+func NewKVStorage(client *vault.Client, mount, path string) *KVStorage {
+	mount = strings.Trim(mount, "/")
+	return &KVStorage{
+		client: client,
+		kv:     client.KVv2(mount),
+		mount:  mount,
+		path:   strings.Trim(path, "/"),
+	}
+}
+
+func (vs *KVStorage) secretPath(index byte) string {
+	return vs.path + "/share_" + strconv.Itoa(int(index))
+}
+
+func (vs *KVStorage) SetShare(index byte, share []byte) error {
+	return vs.SetShareContext(context.Background(), index, share)
+}
+
+func (vs *KVStorage) GetShare(index byte) ([]byte, error) {
+	return vs.GetShareContext(context.Background(), index)
+}
+
+func (vs *KVStorage) ListShares() ([]byte, error) {
+	return vs.ListSharesContext(context.Background())
+}
+
+func (vs *KVStorage) DeleteShare(index byte) error {
+	return vs.DeleteShareContext(context.Background(), index)
+}
+
+func (vs *KVStorage) BatchSet(shares map[byte][]byte) error {
+	return vs.BatchSetContext(context.Background(), shares)
+}
+
+func (vs *KVStorage) SetShareContext(ctx context.Context, index byte, share []byte) error {
+	data := map[string]interface{}{vaultField: base64.StdEncoding.EncodeToString(share)}
+	if _, err := vs.kv.Put(ctx, vs.secretPath(index), data); err != nil {
+		return fmt.Errorf("vaultstore: put share %d: %w", index, err)
+	}
+	return nil
+}
+
+// GetShareContext reads the latest version of a share. A missing secret, or
+// one whose latest version was deleted outside this driver, is ErrNotFound.
+func (vs *KVStorage) GetShareContext(ctx context.Context, index byte) ([]byte, error) {
+	s, err := vs.kv.Get(ctx, vs.secretPath(index))
+	if err != nil {
+		return nil, vaultErr("get", index, err)
+	}
+	v, ok := s.Data[vaultField]
+	if !ok || v == nil {
+		return nil, fmt.Errorf("vaultstore: share %d: %w", index, storage.ErrNotFound)
+	}
+	str, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("vaultstore: share %d: field %q is %T, not a string", index, vaultField, v)
+	}
+	share, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("vaultstore: share %d: %w", index, err)
+	}
+	return share, nil
+}
+
+// ListSharesContext lists the share_<index> keys under the path with the
+// LIST capability on its metadata.
+func (vs *KVStorage) ListSharesContext(ctx context.Context) ([]byte, error) {
+	s, err := vs.client.Logical().ListWithContext(ctx, vs.mount+"/metadata/"+vs.path)
+	if err != nil {
+		return nil, fmt.Errorf("vaultstore: list shares: %w", err)
+	}
+	if s == nil || s.Data == nil {
+		return []byte{}, nil
+	}
+	keys, _ := s.Data["keys"].([]interface{})
+	indices := make([]byte, 0, len(keys))
+	for _, k := range keys {
+		name, _ := k.(string)
+		if !strings.HasPrefix(name, "share_") {
+			continue
+		}
+		i, err := strconv.Atoi(strings.TrimPrefix(name, "share_"))
+		if err != nil || i < 1 || i > 255 {
+			continue
+		}
+		indices = append(indices, byte(i))
+	}
+	return indices, nil
+}
+
+// DeleteShareContext permanently removes a share with all its versions, so
+// it no longer appears in ListShares. This includes a share whose latest
+// version was soft-deleted outside this driver, which ListShares still
+// reports. Vault accepts deleting a path that doesn't exist, so the
+// metadata is read first to report ErrNotFound for it.
+func (vs *KVStorage) DeleteShareContext(ctx context.Context, index byte) error {
+	if _, err := vs.kv.GetMetadata(ctx, vs.secretPath(index)); err != nil {
+		return vaultErr("delete", index, err)
+	}
+	if err := vs.kv.DeleteMetadata(ctx, vs.secretPath(index)); err != nil {
+		return vaultErr("delete", index, err)
+	}
+	return nil
+}
+
+// BatchSetContext writes the shares one secret at a time; Vault has no
+// multi-secret transaction, so a failure part-way leaves earlier shares
+// written.
+func (vs *KVStorage) BatchSetContext(ctx context.Context, shares map[byte][]byte) error {
+	for _, idx := range storage.SortedIndices(shares) {
+		if err := vs.SetShareContext(ctx, idx, shares[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vaultErr maps a missing secret, reported either as ErrSecretNotFound or as
+// a 404 response, to storage.ErrNotFound.
+func vaultErr(op string, index byte, err error) error {
+	var re *vault.ResponseError
+	if errors.Is(err, vault.ErrSecretNotFound) || (errors.As(err, &re) && re.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("vaultstore: share %d: %w", index, storage.ErrNotFound)
+	}
+	return fmt.Errorf("vaultstore: %s share %d: %w", op, index, err)
+}
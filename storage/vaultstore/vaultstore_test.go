@@ -0,0 +1,138 @@
+// storage/vaultstore/vaultstore_test.go
+package vaultstore
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	vault "github.com/hashicorp/vault/api"
+
+	"github.com/oarkflow/shamir/storage"
+	"github.com/oarkflow/shamir/storage/storagetest"
+)
+
+// fakeVault serves the subset of the KV v2 API the driver uses. A secret
+// whose data is nil has had its latest version soft-deleted.
+type fakeVault struct {
+	mu      sync.Mutex
+	secrets map[string]map[string]any
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != "root" || r.Header.Get("X-Vault-Namespace") != "team" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	reply := func(v any) { _ = json.NewEncoder(w).Encode(map[string]any{"data": v}) }
+	p := r.URL.Path
+	switch {
+	case strings.HasPrefix(p, "/v1/kv/data/"):
+		key := strings.TrimPrefix(p, "/v1/kv/data/")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := f.secrets[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			reply(map[string]any{"data": data, "metadata": map[string]any{"version": 1}})
+		default:
+			var body struct{ Data map[string]any }
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.secrets[key] = body.Data
+			reply(map[string]any{"version": 1})
+		}
+	case strings.HasPrefix(p, "/v1/kv/metadata/"):
+		key := strings.TrimPrefix(p, "/v1/kv/metadata/")
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(f.secrets, key)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("list") == "true" || r.Method == "LIST":
+			var keys []any
+			for k := range f.secrets {
+				if strings.HasPrefix(k, key+"/") {
+					keys = append(keys, strings.TrimPrefix(k, key+"/"))
+				}
+			}
+			if len(keys) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			reply(map[string]any{"keys": keys})
+		default:
+			if _, ok := f.secrets[key]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			reply(map[string]any{"current_version": 1, "versions": map[string]any{}})
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newFakeStore(t *testing.T) (*KVStorage, *fakeVault) {
+	t.Helper()
+	fake := &fakeVault{secrets: make(map[string]map[string]any)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	cfg := vault.DefaultConfig()
+	cfg.Address = srv.URL
+	cfg.MaxRetries = 0
+	client, err := vault.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetToken("root")
+	client.SetNamespace("team")
+	return NewKVStorage(client, "kv", "/apps/payments/"), fake
+}
+
+func TestKVStorageConformance(t *testing.T) {
+	storagetest.StorageConformanceTest(t, func() storage.IStorage {
+		st, _ := newFakeStore(t)
+		return st
+	})
+}
+
+func TestKVStorageSoftDeleted(t *testing.T) {
+	st, fake := newFakeStore(t)
+	if err := st.SetShare(3, []byte{3}); err != nil {
+		t.Fatal(err)
+	}
+	fake.mu.Lock()
+	fake.secrets["apps/payments/share_3"] = nil // latest version soft-deleted
+	fake.mu.Unlock()
+
+	if _, err := st.GetShare(3); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("GetShare of a soft-deleted share: %v, want ErrNotFound", err)
+	}
+	if indices, err := st.ListShares(); err != nil || len(indices) != 1 {
+		t.Fatalf("ListShares = %v, %v; want the soft-deleted index", indices, err)
+	}
+	if err := st.DeleteShare(3); err != nil {
+		t.Fatalf("DeleteShare of a soft-deleted share: %v", err)
+	}
+	if indices, err := st.ListShares(); err != nil || len(indices) != 0 {
+		t.Fatalf("ListShares after delete = %v, %v", indices, err)
+	}
+}
+
+func TestKVStorageSendsTokenAndNamespace(t *testing.T) {
+	st, _ := newFakeStore(t)
+	st.client.SetNamespace("other")
+	if err := st.SetShare(1, []byte{1}); err == nil {
+		t.Fatal("SetShare succeeded with the wrong namespace")
+	}
+}
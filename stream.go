@@ -0,0 +1,151 @@
+// stream.go
+package shamir
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CombineFromChannel reconstructs the secret from shares arriving on ch,
+// returning as soon as threshold distinct valid shares have been received.
+// Invalid shares, shares whose header disagrees with the first valid share,
+// and duplicate indices are skipped. It fails if ch closes or ctx is done
+// before quorum is reached.
+func CombineFromChannel(ctx context.Context, ch <-chan []byte, threshold int) ([]byte, error) {
+	return CombineFromChannelFunc(ctx, ch, threshold, nil)
+}
+
+// CombineFromChannelFunc is CombineFromChannel with a callback invoked for
+// every skipped share and the reason it was skipped. onSkip may be nil.
+func CombineFromChannelFunc(ctx context.Context, ch <-chan []byte, threshold int, onSkip func(share []byte, err error)) ([]byte, error) {
+	if threshold < 2 || threshold > 255 {
+		return nil, fmt.Errorf("%w: threshold must be between 2 and 255", ErrInvalidParams)
+	}
+	skip := func(s []byte, err error) {
+		if onSkip != nil {
+			onSkip(s, err)
+		}
+	}
+	var first shareInfo
+	collected := make([][]byte, 0, threshold)
+	byIndex := make(map[byte][]byte, threshold)
+	for len(collected) < threshold {
+		var s []byte
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: have %d of %d: %w", ErrInsufficientShares, len(collected), threshold, ctx.Err())
+		case s, ok = <-ch:
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: channel closed with %d of %d", ErrInsufficientShares, len(collected), threshold)
+		}
+		info, err := parseShare(s)
+		if err != nil {
+			skip(s, err)
+			continue
+		}
+		if int(info.threshold) != threshold {
+			skip(s, fmt.Errorf("%w: share %d has threshold %d", ErrHeaderMismatch, info.index, info.threshold))
+			continue
+		}
+		if len(collected) > 0 && !sameSplit(info, first) {
+			skip(s, splitMismatch(first, info))
+			continue
+		}
+		if prev, dup := byIndex[info.index]; dup {
+			if bytes.Equal(prev, s) {
+				skip(s, fmt.Errorf("%w: %d", ErrDuplicateIndex, info.index))
+			} else {
+				skip(s, fmt.Errorf("%w: %d", ErrConflictingIndex, info.index))
+			}
+			continue
+		}
+		if len(collected) == 0 {
+			first = info
+		}
+		byIndex[info.index] = s
+		collected = append(collected, s)
+	}
+	return Combine(collected)
+}
+
+// CombineFromStorageContext is CombineFromStorage for slow or remote
+// backends: every listed share is requested concurrently, and the secret is
+// reconstructed from the first threshold valid shares to arrive, after which
+// the outstanding requests are cancelled. Storages with context-aware
+// methods (GetShareContext, ListSharesContext, as storage.ContextStorage
+// provides) see the cancellation; for plain storages the outstanding calls
+// are abandoned and finish in the background. The error wraps
+// ErrInsufficientShares and ctx.Err() if ctx is done before quorum.
+func CombineFromStorageContext(ctx context.Context, st IStorage, threshold int) ([]byte, error) {
+	if threshold < 2 || threshold > 255 {
+		return nil, fmt.Errorf("%w: threshold must be between 2 and 255", ErrInvalidParams)
+	}
+	type ctxStorage interface {
+		GetShareContext(ctx context.Context, index byte) ([]byte, error)
+		ListSharesContext(ctx context.Context) ([]byte, error)
+	}
+	cs, _ := st.(ctxStorage)
+	var indices []byte
+	var err error
+	if cs != nil {
+		indices, err = cs.ListSharesContext(ctx)
+	} else {
+		indices, err = listSharesSorted(st)
+	}
+	if err != nil {
+		return nil, err
+	}
+	revoked, _ := st.(interface{ Revoked(index byte) bool })
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		idx   byte
+		share []byte
+		err   error
+	}
+	results := make(chan result, len(indices))
+	pending := 0
+	for _, idx := range indices {
+		if revoked != nil && revoked.Revoked(idx) {
+			continue
+		}
+		pending++
+		go func() {
+			var s []byte
+			var err error
+			if cs != nil {
+				s, err = cs.GetShareContext(ctx, idx)
+			} else {
+				s, err = st.GetShare(idx)
+			}
+			results <- result{idx, s, err}
+		}()
+	}
+
+	set := NewShareSet()
+	var skipped []error
+	for ; pending > 0 && set.Len() < threshold; pending-- {
+		var r result
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %d valid of %d needed: %w", ErrInsufficientShares, set.Len(), threshold, ctx.Err())
+		case r = <-results:
+		}
+		err := r.err
+		if err == nil {
+			err = addWithThreshold(set, r.share, threshold)
+		}
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("share %d: %w", r.idx, err))
+		}
+	}
+	if set.Len() < threshold {
+		return nil, fmt.Errorf("%w: %d valid of %d needed: %w", ErrInsufficientShares, set.Len(), threshold, errors.Join(skipped...))
+	}
+	return set.Combine()
+}
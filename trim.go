@@ -0,0 +1,47 @@
+// trim.go
+package shamir
+
+import "fmt"
+
+// TrimShare removes trailing bytes beyond the length declared in the share
+// header, as appended by storage systems that pad blobs to a fixed block
+// size. The CRC of the trimmed share is verified (shares bound to associated
+// data keep their HMAC for CombineWithAAD to check), and a share shorter than
+// its header declares is rejected. The result aliases share.
+//
+// The padding bytes themselves are discarded unchecked, so TrimShare will
+// accept a valid share followed by arbitrary data. Only use it for stores
+// known to pad, and prefer Combine everywhere else.
+func TrimShare(share []byte) ([]byte, error) {
+	s, want, err := parseHeader(share)
+	if err != nil {
+		return nil, err
+	}
+	if len(share) < want {
+		return nil, fmt.Errorf("%w: share %d shorter than its header declares", ErrLengthMismatch, share[9])
+	}
+	share = share[:want]
+	if s.integrity() == integrityHMAC {
+		// the tag needs the AAD; CombineWithAAD verifies it
+		return share, nil
+	}
+	if _, err := parseShare(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// CombineTrimmed is Combine for shares read back from fixed-block storage:
+// every share is passed through TrimShare first. See TrimShare for the risk
+// of accepting over-length data.
+func CombineTrimmed(shares [][]byte) ([]byte, error) {
+	trimmed := make([][]byte, len(shares))
+	for i, s := range shares {
+		t, err := TrimShare(s)
+		if err != nil {
+			return nil, err
+		}
+		trimmed[i] = t
+	}
+	return Combine(trimmed)
+}
@@ -0,0 +1,233 @@
+// vss_pedersen.go
+package shamir
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Pedersen verifiable secret sharing.
+//
+// The dealer splits the secret s with a random polynomial f (f(0) = s) and
+// a second random "blinding" polynomial r, and publishes commitments
+// C_j = g^a_j * h^b_j mod p to the coefficients a_j of f and b_j of r.
+// Custodian i receives f(i) and r(i) and checks
+//
+//	g^f(i) * h^r(i) == prod_j C_j^(i^j)  (mod p)
+//
+// without learning anything about s. The commitments are perfectly hiding:
+// for any secret there is a blinding polynomial producing the same
+// commitments, so even an unbounded adversary learns nothing about s from
+// them (unlike Feldman commitments g^a_j, which reveal g^s). They are
+// computationally binding: a dealer able to open a commitment two ways
+// would learn log_g(h), i.e. solve a discrete logarithm.
+//
+// Group: p is the 2048-bit safe prime of RFC 3526 group 14, q = (p-1)/2,
+// and arithmetic on shares is in Z_q. g = 4 generates the order-q subgroup
+// of quadratic residues. h is derived by hashing a fixed label to a residue,
+// so nobody knows log_g(h).
+//
+// This is a separate scheme from Split: the secret is encoded as one
+// integer below q, so it is limited to pedersenMaxSecret bytes, and the
+// shares are not in the SHAM format.
+//
+// Layouts (values big-endian, pedersenElem bytes):
+//
+//	share:          idx(1) secretLen(1) f(idx)
+//	blinding share: idx(1) r(idx)
+//	commitment:     C_j
+
+// ErrCommitmentMismatch is returned when a Pedersen share does not match
+// the published commitments.
+var ErrCommitmentMismatch = errors.New("shamir: share does not match commitments")
+
+const (
+	pedersenElem      = 256 // bytes per group or field element
+	pedersenMaxSecret = 255 // keeps the secret below q
+)
+
+var (
+	pedersenP, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7EDEE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3BE39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF6955817183995497CEA956AE515D2261898FA051015728E5A8AACAA68FFFFFFFFFFFFFFFF", 16)
+	pedersenQ    = new(big.Int).Rsh(pedersenP, 1)
+	pedersenG    = big.NewInt(4)
+	pedersenH    = hashToGroup("github.com/oarkflow/shamir pedersen h")
+)
+
+// hashToGroup maps label to a quadratic residue mod p by expanding it with
+// SHA-256 to more than 2048 bits, reducing mod p and squaring.
+func hashToGroup(label string) *big.Int {
+	var buf []byte
+	for ctr := byte(0); len(buf) < pedersenElem+32; ctr++ {
+		sum := sha256.Sum256(append([]byte{ctr}, label...))
+		buf = append(buf, sum[:]...)
+	}
+	x := new(big.Int).SetBytes(buf)
+	x.Mod(x, pedersenP)
+	return x.Exp(x, big.NewInt(2), pedersenP)
+}
+
+// SplitPedersen splits secret (1 to 255 bytes) into n shares requiring t to
+// reconstruct, and returns the t public commitments and each custodian's
+// blinding share. shares[i] and blindingShares[i] go to custodian i+1; the
+// commitments are published to everyone. Reconstruct with CombinePedersen.
+func SplitPedersen(secret []byte, t, n int) (shares, commitments, blindingShares [][]byte, err error) {
+	if err := validateParams(t, n); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(secret) == 0 || len(secret) > pedersenMaxSecret {
+		return nil, nil, nil, fmt.Errorf("%w: Pedersen secrets must be 1 to %d bytes", ErrInvalidParams, pedersenMaxSecret)
+	}
+	f := make([]*big.Int, t)
+	r := make([]*big.Int, t)
+	f[0] = new(big.Int).SetBytes(secret)
+	for j := 0; j < t; j++ {
+		if j > 0 {
+			if f[j], err = rand.Int(rand.Reader, pedersenQ); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		if r[j], err = rand.Int(rand.Reader, pedersenQ); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	defer func() {
+		for j := range f {
+			f[j].SetInt64(0)
+			r[j].SetInt64(0)
+		}
+	}()
+
+	commitments = make([][]byte, t)
+	for j := range commitments {
+		c := pedersenCommit(f[j], r[j])
+		commitments[j] = c.FillBytes(make([]byte, pedersenElem))
+	}
+	shares = make([][]byte, n)
+	blindingShares = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		x := big.NewInt(int64(i + 1))
+		s := make([]byte, 2+pedersenElem)
+		s[0], s[1] = byte(i+1), byte(len(secret))
+		evalModQ(f, x).FillBytes(s[2:])
+		b := make([]byte, 1+pedersenElem)
+		b[0] = byte(i + 1)
+		evalModQ(r, x).FillBytes(b[1:])
+		shares[i], blindingShares[i] = s, b
+	}
+	return shares, commitments, blindingShares, nil
+}
+
+// VerifyPedersenShare checks a custodian's share and blinding share against
+// the published commitments. It fails with ErrCommitmentMismatch if they
+// disagree, and with ErrInvalidParams if any input is malformed.
+func VerifyPedersenShare(share, blindingShare []byte, commitments [][]byte) error {
+	if len(share) != 2+pedersenElem || len(blindingShare) != 1+pedersenElem {
+		return fmt.Errorf("%w: malformed Pedersen share", ErrInvalidParams)
+	}
+	if share[0] == 0 || share[0] != blindingShare[0] {
+		return fmt.Errorf("%w: share and blinding share indices %d and %d", ErrInvalidIndex, share[0], blindingShare[0])
+	}
+	if len(commitments) < 2 {
+		return fmt.Errorf("%w: need at least 2 commitments", ErrInvalidParams)
+	}
+	s := new(big.Int).SetBytes(share[2:])
+	r := new(big.Int).SetBytes(blindingShare[1:])
+	if s.Cmp(pedersenQ) >= 0 || r.Cmp(pedersenQ) >= 0 {
+		return fmt.Errorf("%w: share value out of range", ErrInvalidParams)
+	}
+	x := big.NewInt(int64(share[0]))
+	want := big.NewInt(1)
+	xj := big.NewInt(1)
+	for j, cb := range commitments {
+		c := new(big.Int).SetBytes(cb)
+		if len(cb) != pedersenElem || c.Cmp(big.NewInt(1)) <= 0 || c.Cmp(pedersenP) >= 0 ||
+			new(big.Int).Exp(c, pedersenQ, pedersenP).Cmp(big.NewInt(1)) != 0 {
+			return fmt.Errorf("%w: commitment %d is not a group element", ErrInvalidParams, j)
+		}
+		want.Mul(want, new(big.Int).Exp(c, xj, pedersenP))
+		want.Mod(want, pedersenP)
+		xj.Mul(xj, x)
+		xj.Mod(xj, pedersenQ)
+	}
+	if pedersenCommit(s, r).Cmp(want) != 0 {
+		return fmt.Errorf("%w: share %d", ErrCommitmentMismatch, share[0])
+	}
+	return nil
+}
+
+// CombinePedersen reconstructs the secret from at least threshold Pedersen
+// shares by Lagrange interpolation in Z_q. The threshold isn't recorded in
+// the shares, so every share given is used; verify them with
+// VerifyPedersenShare first.
+func CombinePedersen(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 shares", ErrInsufficientShares)
+	}
+	secretLen := 0
+	seen := make(map[byte]bool, len(shares))
+	xs := make([]*big.Int, len(shares))
+	ys := make([]*big.Int, len(shares))
+	for i, s := range shares {
+		if len(s) != 2+pedersenElem {
+			return nil, fmt.Errorf("%w: malformed Pedersen share %d", ErrLengthMismatch, i)
+		}
+		if s[0] == 0 {
+			return nil, fmt.Errorf("%w: index 0", ErrInvalidIndex)
+		}
+		if seen[s[0]] {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateIndex, s[0])
+		}
+		seen[s[0]] = true
+		if i == 0 {
+			secretLen = int(s[1])
+		} else if int(s[1]) != secretLen {
+			return nil, fmt.Errorf("%w: share %d", ErrHeaderMismatch, s[0])
+		}
+		xs[i] = big.NewInt(int64(s[0]))
+		ys[i] = new(big.Int).SetBytes(s[2:])
+	}
+	secret := new(big.Int)
+	for i := range xs {
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			num.Mul(num, xs[j])
+			num.Mod(num, pedersenQ)
+			d := new(big.Int).Sub(xs[j], xs[i])
+			den.Mul(den, d.Mod(d, pedersenQ))
+			den.Mod(den, pedersenQ)
+		}
+		term := num.Mul(num, den.ModInverse(den, pedersenQ))
+		term.Mul(term, ys[i])
+		secret.Add(secret, term)
+		secret.Mod(secret, pedersenQ)
+	}
+	defer secret.SetInt64(0)
+	if secret.BitLen() > 8*secretLen {
+		return nil, fmt.Errorf("%w: reconstruction does not fit %d bytes", ErrInconsistentShares, secretLen)
+	}
+	return secret.FillBytes(make([]byte, secretLen)), nil
+}
+
+// pedersenCommit returns g^a * h^b mod p.
+func pedersenCommit(a, b *big.Int) *big.Int {
+	c := new(big.Int).Exp(pedersenG, a, pedersenP)
+	c.Mul(c, new(big.Int).Exp(pedersenH, b, pedersenP))
+	return c.Mod(c, pedersenP)
+}
+
+// evalModQ evaluates the polynomial with coefficients coeffs at x in Z_q.
+func evalModQ(coeffs []*big.Int, x *big.Int) *big.Int {
+	y := new(big.Int)
+	for j := len(coeffs) - 1; j >= 0; j-- {
+		y.Mul(y, x)
+		y.Add(y, coeffs[j])
+		y.Mod(y, pedersenQ)
+	}
+	return y
+}
@@ -0,0 +1,118 @@
+// vss_pedersen_test.go
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestPedersenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret []byte
+		t, n   int
+		pick   []int
+	}{
+		{"2 of 3", []byte("pedersen"), 2, 3, []int{2, 0}},
+		{"3 of 5", []byte("verifiable secret sharing"), 3, 5, []int{1, 3, 4}},
+		{"all shares", []byte("all"), 3, 4, []int{0, 1, 2, 3}},
+		{"leading zero bytes", []byte{0, 0, 7}, 2, 3, []int{0, 1}},
+		{"max length", bytes.Repeat([]byte{0xff}, pedersenMaxSecret), 2, 2, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, commitments, blinding, err := SplitPedersen(tt.secret, tt.t, tt.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(commitments) != tt.t {
+				t.Fatalf("got %d commitments, want %d", len(commitments), tt.t)
+			}
+			for i := range shares {
+				if err := VerifyPedersenShare(shares[i], blinding[i], commitments); err != nil {
+					t.Fatalf("valid share %d rejected: %v", i+1, err)
+				}
+			}
+			var subset [][]byte
+			for _, p := range tt.pick {
+				subset = append(subset, shares[p])
+			}
+			got, err := CombinePedersen(subset)
+			if err != nil || !bytes.Equal(got, tt.secret) {
+				t.Fatalf("CombinePedersen = %x, %v; want %x", got, err, tt.secret)
+			}
+		})
+	}
+}
+
+func TestVerifyPedersenShareRejectsTampering(t *testing.T) {
+	shares, commitments, blinding, err := SplitPedersen([]byte("tamper evident"), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherShares, otherCommitments, otherBlinding, err := SplitPedersen([]byte("tamper evident"), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flip := func(b []byte, i int) []byte {
+		c := bytes.Clone(b)
+		c[i] ^= 1
+		return c
+	}
+	reindex := func(b []byte, idx byte) []byte {
+		c := bytes.Clone(b)
+		c[0] = idx
+		return c
+	}
+	overQ := bytes.Clone(shares[0])
+	pedersenQ.FillBytes(overQ[2:])
+
+	tests := []struct {
+		name        string
+		share       []byte
+		blinding    []byte
+		commitments [][]byte
+		wantErr     error
+	}{
+		{"share value", flip(shares[1], len(shares[1])-1), blinding[1], commitments, ErrCommitmentMismatch},
+		{"blinding value", shares[1], flip(blinding[1], 1), commitments, ErrCommitmentMismatch},
+		{"share from another split", otherShares[1], blinding[1], commitments, ErrCommitmentMismatch},
+		{"pair from another split", otherShares[1], otherBlinding[1], commitments, ErrCommitmentMismatch},
+		{"other commitments", shares[1], blinding[1], otherCommitments, ErrCommitmentMismatch},
+		{"dropped commitment", shares[1], blinding[1], commitments[:2], ErrCommitmentMismatch},
+		{"relabelled index", reindex(shares[1], 4), reindex(blinding[1], 4), commitments, ErrCommitmentMismatch},
+		{"index mismatch", shares[1], blinding[2], commitments, ErrInvalidIndex},
+		{"index 0", reindex(shares[1], 0), reindex(blinding[1], 0), commitments, ErrInvalidIndex},
+		{"truncated share", shares[1][:10], blinding[1], commitments, ErrInvalidParams},
+		{"value not below q", overQ, blinding[0], commitments, ErrInvalidParams},
+		{"commitment not in group", shares[1], blinding[1], [][]byte{commitments[0], make([]byte, pedersenElem), commitments[2]}, ErrInvalidParams},
+		{"one commitment", shares[1], blinding[1], commitments[:1], ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyPedersenShare(tt.share, tt.blinding, tt.commitments); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyPedersenShare error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPedersenRejects(t *testing.T) {
+	if _, _, _, err := SplitPedersen(nil, 2, 3); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("empty secret: %v", err)
+	}
+	if _, _, _, err := SplitPedersen(make([]byte, pedersenMaxSecret+1), 2, 3); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("oversized secret: %v", err)
+	}
+	shares, _, _, err := SplitPedersen([]byte("x"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CombinePedersen([][]byte{shares[0], shares[0]}); !errors.Is(err, ErrDuplicateIndex) {
+		t.Fatalf("duplicate shares: %v", err)
+	}
+	if _, err := CombinePedersen(shares[:1]); !errors.Is(err, ErrInsufficientShares) {
+		t.Fatalf("one share: %v", err)
+	}
+}
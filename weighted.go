@@ -0,0 +1,53 @@
+// weighted.go
+package shamir
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SplitWeighted splits the secret among named parties, giving each party as
+// many consecutive x-coordinates (shares) as its weight. A party with weight
+// w counts as w custodians towards the threshold t. Parties are allocated in
+// name order, so the same weights always map to the same indices.
+func SplitWeighted(secret []byte, t int, weights map[string]int) (map[string][][]byte, error) {
+	names := make([]string, 0, len(weights))
+	n := 0
+	for name, w := range weights {
+		if w < 1 {
+			return nil, fmt.Errorf("%w: party %q has weight %d", ErrInvalidParams, name, w)
+		}
+		n += w
+		names = append(names, name)
+	}
+	if n < t || n > 255 {
+		return nil, fmt.Errorf("%w: total weight %d must be between threshold and 255", ErrInvalidParams, n)
+	}
+	sort.Strings(names)
+	shares, err := Split(secret, t, n)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][][]byte, len(names))
+	next := 0
+	for _, name := range names {
+		w := weights[name]
+		out[name] = shares[next : next+w : next+w]
+		next += w
+	}
+	return out, nil
+}
+
+// CombineWeighted flattens the shares held by each party and combines them.
+func CombineWeighted(parties map[string][][]byte) ([]byte, error) {
+	names := make([]string, 0, len(parties))
+	for name := range parties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var shares [][]byte
+	for _, name := range names {
+		shares = append(shares, parties[name]...)
+	}
+	return Combine(shares)
+}